@@ -0,0 +1,117 @@
+package ipaddress
+
+import "encoding/binary"
+
+// /  Creates an address from binary data in network order, like
+// /  the one you get from a network stream. 4 bytes are an IPv4
+// /  address, 16 bytes an IPv6 address.
+// /
+// /    ip = FromBytes([]byte{172, 16, 10, 1}, 24)
+// /
+// /    ip.To_string()
+// /      ///  "172.16.10.1/24"
+// /
+// /  16 bytes in the form ::ffff:a.b.c.d are a mapped address,
+// /  the prefix is checked like with any other IPv6 constructor.
+// /  Other lengths are an error which unwraps to ErrConversion.
+// /
+func FromBytes(b []byte, prefix uint8) ResultIPAddress {
+	return result_ipaddress(FromBytesE(b, prefix))
+}
+
+func FromBytesE(b []byte, prefix uint8) (*IPAddress, error) {
+	return ipaddress_from_bytes(b, prefix)
+}
+
+// /  Creates an IPv4 address from 4 bytes in network order
+// /
+// /    Ipv4FromBytes([4]byte{10, 0, 0, 1}, 8).Unwrap().To_string()
+// /      ///  "10.0.0.1/8"
+// /
+func Ipv4FromBytes(b [4]byte, prefix uint8) ResultIPAddress {
+	return From_u32(binary.BigEndian.Uint32(b[:]), prefix)
+}
+
+// /  Creates an IPv6 address from 16 bytes in network order
+// /
+func Ipv6FromBytes(b [16]byte, prefix uint8) ResultIPAddress {
+	return Ipv6FromUint64s([2]uint64{
+		binary.BigEndian.Uint64(b[:8]),
+		binary.BigEndian.Uint64(b[8:])}, prefix)
+}
+
+// /  Creates an IPv6 address from its upper and lower 64 bits
+// /
+// /    Ipv6FromUint64s([2]uint64{0x20010db800000000, 1}, 64).Unwrap().To_string()
+// /      ///  "2001:db8::1/64"
+// /
+func Ipv6FromUint64s(v [2]uint64, prefix uint8) ResultIPAddress {
+	return Ipv6FromU128(U128{v[0], v[1]}, prefix)
+}
+
+// /  Returns the address in network order, 4 bytes for IPv4
+// /  and 16 bytes for IPv6
+// /
+// /    ip = IPAddress("172.16.10.1/24")
+// /
+// /    ip.To_bytes()
+// /      ///  [172 16 10 1]
+// /
+func (self *IPAddress) To_bytes() []byte {
+	ret := self.Host_address.bytes16()
+	if self.Is_ipv4() {
+		return ret[12:]
+	}
+	return ret[:]
+}
+
+// /  Returns the IPv4 address, or the IPv4 part of a mapped
+// /  address, as 4 bytes in network order. Other IPv6 addresses
+// /  return an error which unwraps to ErrConversion.
+// /
+func (self *IPAddress) To_4bytes() ([4]byte, error) {
+	var ret [4]byte
+	num, err := self.To_u32()
+	if err != nil {
+		return ret, err
+	}
+	binary.BigEndian.PutUint32(ret[:], num)
+	return ret, nil
+}
+
+// /  Returns the address as 16 bytes in network order, IPv4
+// /  is returned in the mapped form ::ffff:a.b.c.d
+// /
+func (self *IPAddress) To_16bytes() [16]byte {
+	return self.to_u128_mapped().bytes16()
+}
+
+// /  Returns the IPv4 address, or the IPv4 part of a mapped
+// /  address, as number
+// /
+// /    ip = IPAddress("172.16.10.1/24")
+// /
+// /    ip.To_u32()
+// /      ///  2886732289
+// /
+func (self *IPAddress) To_u32() (uint32, error) {
+	if self.Is_ipv4() || self.Host_address.Rsh(32) == U128FromUint64(0xffff) {
+		return uint32(self.Host_address.Lo), nil
+	}
+	return 0, new_parse_error(ErrConversion, self.To_string(), 0, "not an IPv4 address")
+}
+
+// /  Returns the upper and lower 64 bits of the address, IPv4
+// /  is returned in the mapped form ::ffff:a.b.c.d
+// /
+func (self *IPAddress) To_uint64s() [2]uint64 {
+	num := self.to_u128_mapped()
+	return [2]uint64{num.Hi, num.Lo}
+}
+
+func (self *IPAddress) to_u128_mapped() U128 {
+	if self.Is_ipv4() {
+		return U128FromUint64(0xffff).Lsh(32).Or(self.Host_address)
+	}
+	return self.Host_address
+}
@@ -0,0 +1,68 @@
+package ipaddress
+
+import "errors"
+import "slices"
+import "testing"
+
+func TestBytes(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestBytes", func(t *MyTesting) {
+		t.Run("test_from_bytes", func(t *MyTesting) {
+			t.assert_string("172.16.10.1/24", FromBytes([]byte{172, 16, 10, 1}, 24).Unwrap().To_string())
+			t.assert_string("2001:db8::1/64", FromBytes([]byte{0x20, 0x01, 0x0d, 0xb8,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, 64).Unwrap().To_string())
+			ip, err := FromBytesE([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 10, 0, 0, 1}, 128)
+			t.assert(err == nil)
+			t.assert(ip.Is_mapped())
+			t.assert_string("10.0.0.1/32", ip.Mapped().To_string())
+			_, err = FromBytesE([]byte{1, 2, 3}, 24)
+			t.assert(errors.Is(err, ErrConversion))
+			_, err = FromBytesE([]byte{1, 2, 3, 4}, 33)
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = FromBytesE([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 10, 0, 0, 1}, 64)
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+		t.Run("test_from_arrays", func(t *MyTesting) {
+			t.assert_string("10.0.0.1/8", Ipv4FromBytes([4]byte{10, 0, 0, 1}, 8).Unwrap().To_string())
+			t.assert(Ipv4FromBytes([4]byte{10, 0, 0, 1}, 33).IsErr())
+			t.assert_string("2001:db8::1/64",
+				Ipv6FromBytes([16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}, 64).Unwrap().To_string())
+			t.assert_string("2001:db8::1/64",
+				Ipv6FromUint64s([2]uint64{0x20010db800000000, 1}, 64).Unwrap().To_string())
+			t.assert(Ipv6FromBytes([16]byte{10: 0xff, 11: 0xff, 12: 10, 15: 1}, 128).Unwrap().Is_mapped())
+		})
+		t.Run("test_accessors", func(t *MyTesting) {
+			ip := Parse("172.16.10.1/24").Unwrap()
+			t.assert(slices.Equal([]byte{172, 16, 10, 1}, ip.To_bytes()))
+			b4, err := ip.To_4bytes()
+			t.assert(err == nil)
+			t.assert(b4 == [4]byte{172, 16, 10, 1})
+			num, _ := ip.To_u32()
+			t.assert(num == 2886732289)
+			t.assert(ip.To_16bytes() == [16]byte{10: 0xff, 11: 0xff, 12: 172, 13: 16, 14: 10, 15: 1})
+			t.assert(ip.To_uint64s() == [2]uint64{0, 0xffffac100a01})
+
+			ip6 := Parse("2001:db8::1/64").Unwrap()
+			t.assert(slices.Equal([]byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, ip6.To_bytes()))
+			t.assert(ip6.To_16bytes() == [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1})
+			t.assert(ip6.To_uint64s() == [2]uint64{0x20010db800000000, 1})
+			_, err = ip6.To_4bytes()
+			t.assert(errors.Is(err, ErrConversion))
+			_, err = ip6.To_u32()
+			t.assert(errors.Is(err, ErrConversion))
+
+			mapped := Parse("::ffff:10.0.0.1").Unwrap()
+			b4, err = mapped.To_4bytes()
+			t.assert(err == nil)
+			t.assert(b4 == [4]byte{10, 0, 0, 1})
+		})
+		t.Run("test_round_trip", func(t *MyTesting) {
+			for _, str := range []string{"10.0.0.1/8", "2001:db8::8:800:200c:417a/64", "::ffff:10.0.0.1/24", "::/0"} {
+				ip := Parse(str).Unwrap()
+				t.assert_string(ip.To_string(), FromBytes(ip.To_bytes(), ip.Prefix.Num).Unwrap().To_string())
+			}
+			ip := Parse("10.0.0.1").Unwrap()
+			t.assert_string("::ffff:a00:1/128", Ipv6FromBytes(ip.To_16bytes(), 128).Unwrap().To_string())
+		})
+	})
+}
@@ -0,0 +1,212 @@
+package ipaddress
+
+import "fmt"
+
+// /  The type of an address as returned by Classify
+// /
+type AddressType int
+
+const (
+	TypeGlobalUnicast AddressType = iota
+	TypeUnspecified
+	TypeLoopback
+	TypeLinkLocal
+	// fec0::/10, deprecated by RFC 3879
+	TypeSiteLocal
+	// fc00::/7, RFC 4193
+	TypeUniqueLocal
+	// IPv4 RFC 1918
+	TypePrivate
+	TypeMulticast
+	// 255.255.255.255
+	TypeBroadcast
+	// ::ffff:0:0/96
+	TypeIpv4Mapped
+	// ::/96, deprecated by RFC 4291
+	TypeIpv4Compatible
+	// 2001::/32, RFC 4380
+	TypeTeredo
+	// 2002::/16, RFC 3056
+	Type6to4
+	// 64:ff9b::/96 and 64:ff9b:1::/48, RFC 6052 and RFC 8215
+	TypeNat64
+	// 0.0.0.0/8 and 240.0.0.0/4
+	TypeReserved
+)
+
+var address_type_names = []string{"global-unicast", "unspecified", "loopback", "link-local",
+	"site-local", "unique-local", "private", "multicast", "broadcast", "ipv4-mapped",
+	"ipv4-compatible", "teredo", "6to4", "nat64", "reserved"}
+
+func (self AddressType) String() string {
+	if self < 0 || int(self) >= len(address_type_names) {
+		return fmt.Sprintf("AddressType(%d)", int(self))
+	}
+	return address_type_names[self]
+}
+
+// /  The scope of an address after RFC 4007 with the values of
+// /  the IPv6 multicast scope field (RFC 7346). Unicast addresses
+// /  only have link-local, site-local (deprecated) or global
+// /  scope, the loopback address has link-local scope.
+// /
+type Scope int
+
+const (
+	ScopeNone              Scope = 0x0
+	ScopeInterfaceLocal    Scope = 0x1
+	ScopeLinkLocal         Scope = 0x2
+	ScopeRealmLocal        Scope = 0x3
+	ScopeAdminLocal        Scope = 0x4
+	ScopeSiteLocal         Scope = 0x5
+	ScopeOrganizationLocal Scope = 0x8
+	ScopeGlobal            Scope = 0xe
+)
+
+func (self Scope) String() string {
+	switch self {
+	case ScopeNone:
+		return "none"
+	case ScopeInterfaceLocal:
+		return "interface-local"
+	case ScopeLinkLocal:
+		return "link-local"
+	case ScopeRealmLocal:
+		return "realm-local"
+	case ScopeAdminLocal:
+		return "admin-local"
+	case ScopeSiteLocal:
+		return "site-local"
+	case ScopeOrganizationLocal:
+		return "organization-local"
+	case ScopeGlobal:
+		return "global"
+	}
+	return fmt.Sprintf("unassigned(%x)", int(self))
+}
+
+// /  Type and scope of an address
+// /
+type Classification struct {
+	Type  AddressType
+	Scope Scope
+}
+
+// /  Classifies the address (the prefix is ignored) by its type
+// /  and scope, for IPv4 and IPv6, unicast and multicast alike:
+// /
+// /    IPAddress("fe80::1%eth0").Classify()
+// /      ///  {TypeLinkLocal ScopeLinkLocal}
+// /    IPAddress("ff05::2").Classify()
+// /      ///  {TypeMulticast ScopeSiteLocal}
+// /    IPAddress("239.255.0.1").Classify()
+// /      ///  {TypeMulticast ScopeSiteLocal}
+// /    IPAddress("::ffff:169.254.0.1").Classify()
+// /      ///  {TypeIpv4Mapped ScopeLinkLocal}
+// /
+// /  IPv4 multicast is scoped after RFC 2365, private IPv4 has
+// /  global scope like in RFC 6724.
+// /
+func (self *IPAddress) Classify() Classification {
+	if self.Is_ipv4() {
+		return classify_ipv4(uint32(self.Host_address.Lo))
+	}
+	return classify_ipv6(self.Host_address)
+}
+
+func in_ipv4_net(addr uint32, net uint32, prefix uint) bool {
+	return addr>>(32-prefix) == net>>(32-prefix)
+}
+
+func classify_ipv4(addr uint32) Classification {
+	switch {
+	case addr == 0:
+		return Classification{TypeUnspecified, ScopeNone}
+	case addr == 0xffffffff:
+		return Classification{TypeBroadcast, ScopeLinkLocal}
+	case in_ipv4_net(addr, 0x00000000, 8), in_ipv4_net(addr, 0xf0000000, 4):
+		return Classification{TypeReserved, ScopeNone}
+	case in_ipv4_net(addr, 0x7f000000, 8):
+		return Classification{TypeLoopback, ScopeLinkLocal}
+	case in_ipv4_net(addr, 0xa9fe0000, 16):
+		return Classification{TypeLinkLocal, ScopeLinkLocal}
+	case in_ipv4_net(addr, 0x0a000000, 8), in_ipv4_net(addr, 0xac100000, 12),
+		in_ipv4_net(addr, 0xc0a80000, 16):
+		return Classification{TypePrivate, ScopeGlobal}
+	case in_ipv4_net(addr, 0xe0000000, 4):
+		return Classification{TypeMulticast, ipv4_multicast_scope(addr)}
+	}
+	return Classification{TypeGlobalUnicast, ScopeGlobal}
+}
+
+// scopes of IPv4 multicast after RFC 2365
+func ipv4_multicast_scope(addr uint32) Scope {
+	switch {
+	case in_ipv4_net(addr, 0xe0000000, 24):
+		return ScopeLinkLocal
+	case in_ipv4_net(addr, 0xefff0000, 16):
+		return ScopeSiteLocal
+	case in_ipv4_net(addr, 0xefc00000, 14):
+		return ScopeOrganizationLocal
+	case in_ipv4_net(addr, 0xef000000, 8):
+		return ScopeAdminLocal
+	}
+	return ScopeGlobal
+}
+
+func classify_ipv6(addr U128) Classification {
+	top16 := addr.Hi >> 48
+	switch {
+	case addr.Is_zero():
+		return Classification{TypeUnspecified, ScopeNone}
+	case addr == U128FromUint64(1):
+		return Classification{TypeLoopback, ScopeLinkLocal}
+	case top16>>8 == 0xff:
+		return Classification{TypeMulticast, Scope(top16 & 0xf)}
+	case addr.Hi == 0 && addr.Lo>>32 == 0xffff:
+		return Classification{TypeIpv4Mapped, classify_ipv4(uint32(addr.Lo)).Scope}
+	case addr.Hi == 0 && addr.Lo>>32 == 0:
+		return Classification{TypeIpv4Compatible, ScopeGlobal}
+	case top16>>6 == 0xfe80>>6:
+		return Classification{TypeLinkLocal, ScopeLinkLocal}
+	case top16>>6 == 0xfec0>>6:
+		return Classification{TypeSiteLocal, ScopeSiteLocal}
+	case top16>>9 == 0xfc00>>9:
+		return Classification{TypeUniqueLocal, ScopeGlobal}
+	case addr.Hi>>32 == 0x20010000:
+		return Classification{TypeTeredo, ScopeGlobal}
+	case top16 == 0x2002:
+		return Classification{Type6to4, ScopeGlobal}
+	case addr.Hi == 0x0064ff9b00000000 && addr.Lo>>32 == 0,
+		addr.Hi>>16 == 0x0064ff9b0001:
+		return Classification{TypeNat64, ScopeGlobal}
+	}
+	return Classification{TypeGlobalUnicast, ScopeGlobal}
+}
+
+// /  Checks if the address is a link-local unicast address,
+// /  169.254.0.0/16 or fe80::/10
+// /
+func (self *IPAddress) Is_link_local() bool {
+	return self.Classify().Type == TypeLinkLocal
+}
+
+// /  Checks if the address is a unique local address fc00::/7
+// /
+func (self *IPAddress) Is_unique_local() bool {
+	return self.Classify().Type == TypeUniqueLocal
+}
+
+// /  Checks if the address is a deprecated site-local address
+// /  fec0::/10
+// /
+func (self *IPAddress) Is_site_local() bool {
+	return self.Classify().Type == TypeSiteLocal
+}
+
+// /  Checks if the address is a multicast address,
+// /  224.0.0.0/4 or ff00::/8
+// /
+func (self *IPAddress) Is_multicast() bool {
+	return self.Classify().Type == TypeMulticast
+}
@@ -0,0 +1,76 @@
+package ipaddress
+
+import "testing"
+
+func TestClassify(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestClassify", func(t *MyTesting) {
+		classify := func(str string) string {
+			c := Parse(str).Unwrap().Classify()
+			return c.Type.String() + " " + c.Scope.String()
+		}
+		t.Run("test_ipv6_unicast", func(t *MyTesting) {
+			t.assert_string("unspecified none", classify("::"))
+			t.assert_string("loopback link-local", classify("::1"))
+			t.assert_string("link-local link-local", classify("fe80::1%eth0"))
+			t.assert_string("link-local link-local", classify("febf::1"))
+			t.assert_string("site-local site-local", classify("fec0::1"))
+			t.assert_string("unique-local global", classify("fc00::1"))
+			t.assert_string("unique-local global", classify("fdff::1"))
+			t.assert_string("ipv4-mapped global", classify("::ffff:8.8.8.8"))
+			t.assert_string("ipv4-mapped link-local", classify("::ffff:169.254.1.1"))
+			t.assert_string("ipv4-compatible global", classify("::808:808"))
+			t.assert_string("teredo global", classify("2001:0:4136:e378::1"))
+			t.assert_string("6to4 global", classify("2002:c000:204::1"))
+			t.assert_string("nat64 global", classify("64:ff9b::192.0.2.33"))
+			t.assert_string("nat64 global", classify("64:ff9b:1::1"))
+			t.assert_string("global-unicast global", classify("2001:db8::1"))
+			t.assert_string("global-unicast global", classify("2001:1::1"))
+			t.assert_string("global-unicast global", classify("64:ff9b::1:0:0"))
+		})
+		t.Run("test_ipv6_multicast", func(t *MyTesting) {
+			t.assert_string("multicast interface-local", classify("ff01::1"))
+			t.assert_string("multicast link-local", classify("ff02::1"))
+			t.assert_string("multicast realm-local", classify("ff03::1"))
+			t.assert_string("multicast admin-local", classify("ff04::1"))
+			t.assert_string("multicast site-local", classify("ff05::2"))
+			t.assert_string("multicast organization-local", classify("ff18::1"))
+			t.assert_string("multicast global", classify("ff3e:40:2001:db8::1"))
+			t.assert_string("multicast none", classify("ff00::1"))
+			t.assert_string("multicast unassigned(6)", classify("ff06::1"))
+		})
+		t.Run("test_ipv4", func(t *MyTesting) {
+			t.assert_string("unspecified none", classify("0.0.0.0"))
+			t.assert_string("reserved none", classify("0.1.2.3"))
+			t.assert_string("reserved none", classify("240.0.0.1"))
+			t.assert_string("broadcast link-local", classify("255.255.255.255"))
+			t.assert_string("loopback link-local", classify("127.0.0.1"))
+			t.assert_string("link-local link-local", classify("169.254.0.1"))
+			t.assert_string("private global", classify("10.1.2.3"))
+			t.assert_string("private global", classify("172.31.255.255"))
+			t.assert_string("global-unicast global", classify("172.32.0.0"))
+			t.assert_string("private global", classify("192.168.0.1"))
+			t.assert_string("global-unicast global", classify("8.8.8.8"))
+			t.assert_string("multicast link-local", classify("224.0.0.251"))
+			t.assert_string("multicast global", classify("224.0.1.1"))
+			t.assert_string("multicast site-local", classify("239.255.255.250"))
+			t.assert_string("multicast organization-local", classify("239.192.0.1"))
+			t.assert_string("multicast admin-local", classify("239.1.2.3"))
+		})
+		t.Run("test_predicates", func(t *MyTesting) {
+			t.assert(Parse("fe80::/10").Unwrap().Is_link_local())
+			t.assert(Parse("169.254.1.1").Unwrap().Is_link_local())
+			t.assert(!Parse("fec0::1").Unwrap().Is_link_local())
+			t.assert(Parse("fec0::1").Unwrap().Is_site_local())
+			t.assert(Parse("fc00::1").Unwrap().Is_unique_local())
+			t.assert(!Parse("fe00::1").Unwrap().Is_unique_local())
+			t.assert(Parse("ff02::1").Unwrap().Is_multicast())
+			t.assert(Parse("224.0.0.1").Unwrap().Is_multicast())
+			t.assert(!Parse("223.255.255.255").Unwrap().Is_multicast())
+			t.assert(Parse("127.0.0.1/8").Unwrap().Is_loopback())
+			t.assert(Parse("::1").Unwrap().Is_loopback())
+			t.assert(!Parse("::ffff:127.0.0.1").Unwrap().Is_loopback())
+			t.assert_string("AddressType(99)", AddressType(99).String())
+		})
+	})
+}
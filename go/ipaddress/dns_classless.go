@@ -0,0 +1,154 @@
+package ipaddress
+
+import "fmt"
+import "math/bits"
+import "strings"
+
+// /  Selects how the child zone of a classless delegation
+// /  (RFC 2317) is named:
+// /
+// /  * Rfc2317Slash: network octet and prefix, "0/26.2.0.192.in-addr.arpa"
+// /  * Rfc2317Range: first and last octet, "0-63.2.0.192.in-addr.arpa"
+// /
+type Rfc2317Style int
+
+const (
+	Rfc2317Slash Rfc2317Style = iota
+	Rfc2317Range
+)
+
+// /  A CNAME record the parent zone of a classless delegation
+// /  needs, Owner points to Target in the child zone
+// /
+type DnsCname struct {
+	Owner  string
+	Target string
+}
+
+func rfc2317_style(style []Rfc2317Style) Rfc2317Style {
+	if len(style) == 0 {
+		return Rfc2317Slash
+	}
+	return style[0]
+}
+
+// /  Checks if the network is an IPv4 network smaller than a
+// /  /24 which can't be delegated on an octet boundary, these
+// /  are delegated with the CNAMEs of RFC 2317
+// /
+// /    IPAddress("192.0.2.0/26").Is_classless()
+// /      ///  true
+// /    IPAddress("192.0.2.0/24").Is_classless()
+// /      ///  false
+// /
+func (self *IPAddress) Is_classless() bool {
+	return self.Is_ipv4() && self.Prefix.Num > 24 && self.Prefix.Num < 32
+}
+
+// /  Returns the name of the child zone of a classless
+// /  delegation (RFC 2317)
+// /
+// /    ip = IPAddress("192.0.2.0/26")
+// /
+// /    ip.Dns_classless_domain()
+// /      ///  "0/26.2.0.192.in-addr.arpa"
+// /    ip.Dns_classless_domain(Rfc2317Range)
+// /      ///  "0-63.2.0.192.in-addr.arpa"
+// /
+// /  Networks which are not classless return an error which
+// /  unwraps to ErrPrefixRange, use Dns_rev_domains for them.
+// /
+func (self *IPAddress) Dns_classless_domain(style ...Rfc2317Style) (string, error) {
+	if !self.Is_classless() {
+		return "", new_parse_error(ErrPrefixRange, self.To_string(), 0,
+			"classless delegation needs an IPv4 prefix between 25 and 31, got: %d", self.Prefix.Num)
+	}
+	first := self.Network().Host_address.Lo & 0xff
+	parent := self.Change_prefix(24).Unwrap().Network().Dns_reverse()
+	if rfc2317_style(style) == Rfc2317Range {
+		last := self.Broadcast().Host_address.Lo & 0xff
+		return fmt.Sprintf("%d-%d.%s", first, last, parent), nil
+	}
+	return fmt.Sprintf("%d/%d.%s", first, self.Prefix.Num, parent), nil
+}
+
+// /  Returns the zones which are delegated for the network. A
+// /  classless IPv4 network is a single RFC 2317 child zone,
+// /  every other network is split into the covering octet (IPv4)
+// /  or nibble (IPv6) aligned zones like Dns_rev_domains does:
+// /
+// /    IPAddress("192.0.2.64/26").Dns_delegation_domains()
+// /      ///  ["64/26.2.0.192.in-addr.arpa"]
+// /    IPAddress("2001:db8::/62").Dns_delegation_domains()
+// /      ///  ["0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", ...
+// /      ///   "3.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"]
+// /
+func (self *IPAddress) Dns_delegation_domains(style ...Rfc2317Style) []string {
+	if domain, err := self.Dns_classless_domain(style...); err == nil {
+		return []string{domain}
+	}
+	return self.Dns_rev_domains()
+}
+
+// /  Returns the CNAME records the parent zone needs for every
+// /  address of a classless network
+// /
+// /    ip = IPAddress("192.0.2.0/30")
+// /
+// /    ip.Dns_classless_cnames()
+// /      ///  [{"0.2.0.192.in-addr.arpa", "0.0/30.2.0.192.in-addr.arpa"},
+// /      ///   {"1.2.0.192.in-addr.arpa", "1.0/30.2.0.192.in-addr.arpa"},
+// /      ///   {"2.2.0.192.in-addr.arpa", "2.0/30.2.0.192.in-addr.arpa"},
+// /      ///   {"3.2.0.192.in-addr.arpa", "3.0/30.2.0.192.in-addr.arpa"}]
+// /
+func (self *IPAddress) Dns_classless_cnames(style ...Rfc2317Style) ([]DnsCname, error) {
+	domain, err := self.Dns_classless_domain(style...)
+	if err != nil {
+		return nil, err
+	}
+	ret := []DnsCname{}
+	for ip := range self.Each_seq() {
+		host := host_of(ip)
+		octet := host.Host_address.Lo & 0xff
+		ret = append(ret, DnsCname{
+			Owner:  host.Dns_reverse(),
+			Target: fmt.Sprintf("%d.%s", octet, domain),
+		})
+	}
+	return ret, nil
+}
+
+// parses the child zone of a classless delegation, the first
+// label is "first/prefix" or "first-last"
+func parse_rfc2317_labels(name string, labels []string, labels_ofs []int) (*IPAddress, error) {
+	parent, err := parse_dns_labels(name, IpBitsV4(), labels[1:], labels_ofs[1:])
+	if err != nil {
+		return nil, err
+	}
+	first_str, rest, slash := strings.Cut(labels[0], "/")
+	if !slash {
+		first_str, rest, _ = strings.Cut(labels[0], "-")
+	}
+	first, ok_first := parse_dns_label(IpBitsV4(), first_str)
+	second, ok_second := parse_dns_label(IpBitsV4(), rest)
+	if !ok_first || !ok_second {
+		return nil, new_parse_error(ErrBadOctet, name, labels_ofs[0], "illegal label %q", labels[0])
+	}
+	prefix := second
+	if !slash {
+		// the range has to be a block of a power of two
+		size := second - first + 1
+		if second < first || size&(size-1) != 0 {
+			return nil, new_parse_error(ErrNonContiguousMask, name, labels_ofs[0], "range %q is not a network", labels[0])
+		}
+		prefix = 32 - uint64(bits.TrailingZeros64(size))
+	}
+	if prefix <= 24 || prefix >= 32 {
+		return nil, new_parse_error(ErrPrefixRange, name, labels_ofs[0], "classless prefix must be between 25 and 31")
+	}
+	host_mask := uint64(1)<<(32-prefix) - 1
+	if first&host_mask != 0 {
+		return nil, new_parse_error(ErrInvalidAddress, name, labels_ofs[0], "%d is not the start of a /%d", first, prefix)
+	}
+	return From_u32(uint32(parent.Host_address.Lo|first), uint8(prefix)).Unwrap(), nil
+}
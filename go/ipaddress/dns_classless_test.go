@@ -0,0 +1,84 @@
+package ipaddress
+
+import "errors"
+import "testing"
+
+func TestDnsClassless(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestDnsClassless", func(t *MyTesting) {
+		t.Run("test_is_classless", func(t *MyTesting) {
+			t.assert(Parse("192.0.2.0/26").Unwrap().Is_classless())
+			t.assert(Parse("192.0.2.0/31").Unwrap().Is_classless())
+			t.assert(!Parse("192.0.2.0/24").Unwrap().Is_classless())
+			t.assert(!Parse("192.0.2.1/32").Unwrap().Is_classless())
+			t.assert(!Parse("192.0.0.0/22").Unwrap().Is_classless())
+			t.assert(!Parse("2001:db8::/62").Unwrap().Is_classless())
+		})
+		t.Run("test_domain", func(t *MyTesting) {
+			ip := Parse("192.0.2.77/26").Unwrap()
+			domain, err := ip.Dns_classless_domain()
+			t.assert(err == nil)
+			t.assert_string("64/26.2.0.192.in-addr.arpa", domain)
+			domain, _ = ip.Dns_classless_domain(Rfc2317Range)
+			t.assert_string("64-127.2.0.192.in-addr.arpa", domain)
+			domain, _ = Parse("192.0.2.0/25").Unwrap().Dns_classless_domain()
+			t.assert_string("0/25.2.0.192.in-addr.arpa", domain)
+			_, err = Parse("192.0.2.0/24").Unwrap().Dns_classless_domain()
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Parse("2001:db8::/62").Unwrap().Dns_classless_domain()
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+		t.Run("test_delegation_domains", func(t *MyTesting) {
+			t.assert_string_array([]string{"64-127.2.0.192.in-addr.arpa"},
+				Parse("192.0.2.64/26").Unwrap().Dns_delegation_domains(Rfc2317Range))
+			t.assert_string_array([]string{"2.0.192.in-addr.arpa"},
+				Parse("192.0.2.0/24").Unwrap().Dns_delegation_domains())
+			t.assert_string_array([]string{"0.0.192.in-addr.arpa", "1.0.192.in-addr.arpa"},
+				Parse("192.0.0.0/23").Unwrap().Dns_delegation_domains())
+			t.assert_string_array([]string{
+				"0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+				"1.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+				"2.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+				"3.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+				Parse("2001:db8::/62").Unwrap().Dns_delegation_domains())
+			t.assert_string_array([]string{"0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+				Parse("2001:db8::/64").Unwrap().Dns_delegation_domains())
+		})
+		t.Run("test_cnames", func(t *MyTesting) {
+			cnames, err := Parse("192.0.2.64/30").Unwrap().Dns_classless_cnames()
+			t.assert(err == nil)
+			t.assert_int(4, len(cnames))
+			t.assert(cnames[0] == DnsCname{"64.2.0.192.in-addr.arpa", "64.64/30.2.0.192.in-addr.arpa"})
+			t.assert(cnames[3] == DnsCname{"67.2.0.192.in-addr.arpa", "67.64/30.2.0.192.in-addr.arpa"})
+			cnames, _ = Parse("192.0.2.0/26").Unwrap().Dns_classless_cnames(Rfc2317Range)
+			t.assert_int(64, len(cnames))
+			t.assert(cnames[63] == DnsCname{"63.2.0.192.in-addr.arpa", "63.0-63.2.0.192.in-addr.arpa"})
+			_, err = Parse("192.0.2.0/24").Unwrap().Dns_classless_cnames()
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+		t.Run("test_parse", func(t *MyTesting) {
+			for _, str := range []string{"192.0.2.0/25", "192.0.2.64/26", "192.0.2.252/30", "192.0.2.6/31"} {
+				ip := Parse(str).Unwrap()
+				for _, style := range []Rfc2317Style{Rfc2317Slash, Rfc2317Range} {
+					domain, _ := ip.Dns_classless_domain(style)
+					back, err := ParseDnsReverse(domain)
+					t.assert(err == nil)
+					t.assert_string(str, back.To_string())
+				}
+			}
+			_, err := ParseDnsReverse("64-100.2.0.192.in-addr.arpa")
+			t.assert(errors.Is(err, ErrNonContiguousMask))
+			_, err = ParseDnsReverse("32/26.2.0.192.in-addr.arpa")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseDnsReverse("0/24.2.0.192.in-addr.arpa")
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = ParseDnsReverse("0/2x.2.0.192.in-addr.arpa")
+			t.assert(errors.Is(err, ErrBadOctet))
+			_, err = ParseDnsReverse("0/26.2.0.in-addr.arpa")
+			t.assert(errors.Is(err, ErrBadOctet))
+			_, err = ParseDnsReverse("0/26.2.0.256.in-addr.arpa")
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(9, parse_error_of(err).Offset)
+		})
+	})
+}
@@ -0,0 +1,87 @@
+package ipaddress
+
+import "strconv"
+import "strings"
+
+// /  Parses a reverse DNS name, the inverse of Dns_reverse.
+// /  Every label is an octet (in-addr.arpa) or a nibble
+// /  (ip6.arpa), so the network has the prefix of the labels
+// /  which are given:
+// /
+// /    ip, _ := ParseDnsReverse("16.172.in-addr.arpa")
+// /    ip.To_string()
+// /      ///  "172.16.0.0/16"
+// /
+// /    ip, _ = ParseDnsReverse("8.b.d.0.1.0.0.2.ip6.arpa.")
+// /    ip.To_string()
+// /      ///  "2001:db8::/32"
+// /
+// /  The child zones of RFC 2317 like "0/26.2.0.192.in-addr.arpa"
+// /  or "0-63.2.0.192.in-addr.arpa" return the delegated network.
+// /
+// /  The name is matched case insensitive, a trailing dot is
+// /  allowed. Labels with leading zeros, more than one hex digit
+// /  or out of range return an error which unwraps to
+// /  ErrBadOctet.
+// /
+func ParseDnsReverse(name string) (*IPAddress, error) {
+	fqdn := strings.TrimSuffix(name, ".")
+	for _, ip_bits := range []*IpBits{IpBitsV4(), IpBitsV6()} {
+		domain := ip_bits.Rev_domain
+		if !strings.EqualFold(fqdn, domain) &&
+			!(len(fqdn) > len(domain) && fqdn[len(fqdn)-len(domain)-1] == '.' &&
+				strings.EqualFold(fqdn[len(fqdn)-len(domain):], domain)) {
+			continue
+		}
+		labels := []string{}
+		labels_ofs := []int{}
+		if len(fqdn) > len(domain) {
+			labels, labels_ofs = split_ofs(fqdn[:len(fqdn)-len(domain)-1], ".")
+		}
+		return parse_dns_labels(name, ip_bits, labels, labels_ofs)
+	}
+	return nil, new_parse_error(ErrInvalidAddress, name, 0, "not below in-addr.arpa or ip6.arpa")
+}
+
+func parse_dns_labels(name string, ip_bits *IpBits, labels []string, labels_ofs []int) (*IPAddress, error) {
+	max_labels := int(ip_bits.Bits / ip_bits.Dns_bits)
+	if len(labels) > max_labels {
+		return nil, new_parse_error(ErrInvalidAddress, name, labels_ofs[0],
+			"%s has at most %d labels, got: %d", ip_bits.Rev_domain, max_labels, len(labels))
+	}
+	if ip_bits.Version == FamilyV4 && len(labels) == max_labels &&
+		strings.ContainsAny(labels[0], "/-") {
+		return parse_rfc2317_labels(name, labels, labels_ofs)
+	}
+	host := U128{}
+	// the labels are written from the lowest to the highest part
+	for i := len(labels) - 1; i >= 0; i-- {
+		part, ok := parse_dns_label(ip_bits, labels[i])
+		if !ok {
+			return nil, new_parse_error(ErrBadOctet, name, labels_ofs[i], "illegal label %q", labels[i])
+		}
+		host = host.Lsh(uint(ip_bits.Dns_bits)).Or(U128FromUint64(part))
+	}
+	prefix := uint8(len(labels)) * ip_bits.Dns_bits
+	host = host.Lsh(uint(ip_bits.Bits - prefix))
+	if ip_bits.Version == FamilyV4 {
+		return From_u32(uint32(host.Lo), prefix).Unwrap(), nil
+	}
+	return Ipv6FromU128(host, prefix).Unwrap(), nil
+}
+
+func parse_dns_label(ip_bits *IpBits, label string) (uint64, bool) {
+	if ip_bits.Version == FamilyV6 {
+		if len(label) != 1 || !is_hex_char(label[0]) {
+			return 0, false
+		}
+		num, _ := strconv.ParseUint(label, 16, 8)
+		return num, true
+	}
+	if label == "" || len(label) > 3 || (len(label) > 1 && label[0] == '0') ||
+		strings.TrimLeft(label, "0123456789") != "" {
+		return 0, false
+	}
+	num, _ := strconv.ParseUint(label, 10, 16)
+	return num, num < 256
+}
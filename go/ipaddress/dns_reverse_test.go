@@ -0,0 +1,66 @@
+package ipaddress
+
+import "errors"
+import "testing"
+
+func TestDnsReverse(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestDnsReverse", func(t *MyTesting) {
+		parse := func(name string) string {
+			ip, err := ParseDnsReverse(name)
+			if err != nil {
+				return err.Error()
+			}
+			return ip.To_string()
+		}
+		t.Run("test_ipv4", func(t *MyTesting) {
+			t.assert_string("172.16.0.0/16", parse("16.172.in-addr.arpa"))
+			t.assert_string("10.0.0.1/32", parse("1.0.0.10.in-addr.arpa."))
+			t.assert_string("192.0.2.0/24", parse("2.0.192.IN-ADDR.ARPA"))
+			t.assert_string("172.0.0.0/8", parse("172.in-addr.arpa"))
+			t.assert_string("0.0.0.0/0", parse("in-addr.arpa"))
+		})
+		t.Run("test_ipv6", func(t *MyTesting) {
+			t.assert_string("2001:db8::/32", parse("8.b.d.0.1.0.0.2.ip6.arpa."))
+			t.assert_string("2001:db8::/36", parse("0.8.B.D.0.1.0.0.2.ip6.arpa"))
+			t.assert_string("f000::/4", parse("f.ip6.arpa"))
+			t.assert_string("::/0", parse("ip6.arpa"))
+			t.assert_string("2001:db8::1/128",
+				parse("1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"))
+		})
+		t.Run("test_round_trip", func(t *MyTesting) {
+			for _, str := range []string{"172.16.0.0/16", "10.0.0.1/32", "2001:db8::/32", "2001:db8::1/128", "fe80::/12"} {
+				ip := Parse(str).Unwrap()
+				back, err := ParseDnsReverse(ip.Dns_reverse())
+				t.assert(err == nil)
+				t.assert_string(str, back.To_string())
+			}
+			for _, name := range Parse("172.17.100.50/15").Unwrap().Dns_rev_domains() {
+				ip, _ := ParseDnsReverse(name)
+				t.assert_int(16, int(ip.Prefix.Num))
+			}
+		})
+		t.Run("test_errors", func(t *MyTesting) {
+			_, err := ParseDnsReverse("16.172.example.com")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseDnsReverse("16.172xin-addr.arpa")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseDnsReverse("016.172.in-addr.arpa")
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(0, parse_error_of(err).Offset)
+			_, err = ParseDnsReverse("16.256.in-addr.arpa")
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(3, parse_error_of(err).Offset)
+			_, err = ParseDnsReverse("16..in-addr.arpa")
+			t.assert(errors.Is(err, ErrBadOctet))
+			_, err = ParseDnsReverse("1.1.1.1.1.in-addr.arpa")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseDnsReverse("10.b.d.0.1.0.0.2.ip6.arpa")
+			t.assert(errors.Is(err, ErrBadOctet))
+			_, err = ParseDnsReverse("g.ip6.arpa")
+			t.assert(errors.Is(err, ErrBadOctet))
+			_, err = ParseDnsReverse("0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+		})
+	})
+}
@@ -0,0 +1,160 @@
+package ipaddress
+
+import "math/big"
+import "strings"
+
+// /  Returns the address as decimal integer, like databases
+// /  store it
+// /
+// /    ip = IPAddress("10.0.0.1/8")
+// /
+// /    ip.To_decimal()
+// /      ///  "167772161"
+// /
+func (self *IPAddress) To_decimal() string {
+	return self.Host_address.Text(10)
+}
+
+// /  Creates a host address of the given family from a decimal
+// /  integer
+// /
+// /    FromDecimal("167772161", FamilyV4).Unwrap().To_string()
+// /      ///  "10.0.0.1/32"
+// /
+func FromDecimal(str string, family Family) ResultIPAddress {
+	return result_ipaddress(FromDecimalE(str, family))
+}
+
+func FromDecimalE(str string, family Family) (*IPAddress, error) {
+	if str == "" || strings.TrimLeft(str, "0123456789") != "" {
+		return nil, new_parse_error(ErrInvalidAddress, str, len(str)-len(strings.TrimLeft(str, "0123456789")),
+			"not a decimal number")
+	}
+	num, _ := big.NewInt(0).SetString(str, 10)
+	ip_bits := IpBitsV6()
+	if family == FamilyV4 {
+		ip_bits = IpBitsV4()
+	}
+	if num.BitLen() > int(ip_bits.Bits) {
+		return nil, new_parse_error(ErrInvalidAddress, str, 0, "number too large for %d bits", ip_bits.Bits)
+	}
+	host, _ := U128FromBig(num)
+	if family == FamilyV4 {
+		return From_u32(uint32(host.Lo), 32).Unwrap(), nil
+	}
+	return Ipv6FromU128(host, 128).Unwrap(), nil
+}
+
+const base85_chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
+
+// /  Returns the IPv6 address in the base 85 notation of
+// /  RFC 1924, IPv4 returns an error which unwraps to
+// /  ErrConversion
+// /
+// /    ip = IPAddress("1080::8:800:200c:417a")
+// /
+// /    ip.To_base85()
+// /      ///  "4)+k&C#VzJ4br>0wv%Yp"
+// /
+func (self *IPAddress) To_base85() (string, error) {
+	if !self.Is_ipv6() {
+		return "", new_parse_error(ErrConversion, self.To_string(), 0, "base85 needs an IPv6 address")
+	}
+	num := self.Host_address.Big()
+	base := big.NewInt(85)
+	digit := big.NewInt(0)
+	ret := make([]byte, 20)
+	for i := len(ret) - 1; i >= 0; i-- {
+		num.DivMod(num, base, digit)
+		ret[i] = base85_chars[digit.Int64()]
+	}
+	return string(ret), nil
+}
+
+// /  Creates an IPv6 host address from the 20 characters of
+// /  the RFC 1924 notation
+// /
+func FromBase85(str string) ResultIPAddress {
+	return result_ipaddress(FromBase85E(str))
+}
+
+func FromBase85E(str string) (*IPAddress, error) {
+	if len(str) != 20 {
+		return nil, new_parse_error(ErrInvalidAddress, str, 0, "base85 needs 20 characters, got: %d", len(str))
+	}
+	num := big.NewInt(0)
+	base := big.NewInt(85)
+	for i := 0; i < len(str); i++ {
+		digit := strings.IndexByte(base85_chars, str[i])
+		if digit < 0 {
+			return nil, new_parse_error(ErrInvalidAddress, str, i, "not a base85 character %q", str[i])
+		}
+		num.Mul(num, base).Add(num, big.NewInt(int64(digit)))
+	}
+	host, err := U128FromBig(num)
+	if err != nil {
+		return nil, new_parse_error(ErrInvalidAddress, str, 0, "number too large for 128 bits")
+	}
+	return Ipv6FromU128(host, 128).Unwrap(), nil
+}
+
+const ipv6_literal_suffix = ".ipv6-literal.net"
+
+// /  Returns the IPv6 address as ipv6-literal.net name, which
+// /  Windows accepts as host in UNC paths. The colons become
+// /  dashes and the zone follows an s:
+// /
+// /    ip = IPAddress("fe80::1%4")
+// /
+// /    ip.To_ipv6_literal()
+// /      ///  "fe80--1s4.ipv6-literal.net"
+// /
+// /  IPv4 returns an error which unwraps to ErrConversion.
+// /
+func (self *IPAddress) To_ipv6_literal() (string, error) {
+	if !self.Is_ipv6() {
+		return "", new_parse_error(ErrConversion, self.To_string(), 0, "ipv6-literal.net needs an IPv6 address")
+	}
+	ret := strings.ReplaceAll(self.Without_zone().To_s(), ":", "-")
+	if self.Zone != "" {
+		ret += "s" + self.Zone
+	}
+	return ret + ipv6_literal_suffix, nil
+}
+
+// /  Parses an ipv6-literal.net name like
+// /  "2001-db8--1.ipv6-literal.net" or "fe80--1s4.ipv6-literal.net",
+// /  the suffix is matched case insensitive
+// /
+func FromIpv6Literal(str string) ResultIPAddress {
+	return result_ipaddress(FromIpv6LiteralE(str))
+}
+
+func FromIpv6LiteralE(str string) (*IPAddress, error) {
+	name := strings.TrimSuffix(str, ".")
+	if len(name) <= len(ipv6_literal_suffix) ||
+		!strings.EqualFold(name[len(name)-len(ipv6_literal_suffix):], ipv6_literal_suffix) {
+		return nil, new_parse_error(ErrInvalidAddress, str, 0, "missing %s suffix", ipv6_literal_suffix[1:])
+	}
+	label := name[:len(name)-len(ipv6_literal_suffix)]
+	addr := label
+	zone := ""
+	if s := strings.IndexAny(label, "sS"); s >= 0 {
+		addr = label[:s]
+		zone = label[s+1:]
+		if _, err := parse_zone(str, zone, s+1); err != nil {
+			return nil, err
+		}
+	}
+	if strings.ContainsAny(addr, ":%/") {
+		return nil, new_parse_error(ErrInvalidAddress, str, strings.IndexAny(addr, ":%/"), "unexpected character")
+	}
+	ip, err := Ipv6NewE(strings.ReplaceAll(addr, "-", ":"))
+	if err != nil {
+		return nil, err.(*ParseError).rebase(str, 0)
+	}
+	if zone != "" {
+		return ip.With_zone(zone)
+	}
+	return ip, nil
+}
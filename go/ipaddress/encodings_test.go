@@ -0,0 +1,75 @@
+package ipaddress
+
+import "errors"
+import "testing"
+
+func TestEncodings(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestEncodings", func(t *MyTesting) {
+		t.Run("test_decimal", func(t *MyTesting) {
+			t.assert_string("167772161", Parse("10.0.0.1/8").Unwrap().To_decimal())
+			t.assert_string("42540766411282592856903984951653826561", Parse("2001:db8::1").Unwrap().To_decimal())
+			t.assert_string("10.0.0.1/32", FromDecimal("167772161", FamilyV4).Unwrap().To_string())
+			t.assert_string("2001:db8::1/128",
+				FromDecimal("42540766411282592856903984951653826561", FamilyV6).Unwrap().To_string())
+			t.assert_string("::a00:1/128", FromDecimal("167772161", FamilyV6).Unwrap().To_string())
+			t.assert_string("255.255.255.255/32", FromDecimal("4294967295", FamilyV4).Unwrap().To_string())
+			_, err := FromDecimalE("4294967296", FamilyV4)
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = FromDecimalE("340282366920938463463374607431768211456", FamilyV6)
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = FromDecimalE("12a4", FamilyV4)
+			t.assert_int(2, parse_error_of(err).Offset)
+			t.assert(FromDecimal("", FamilyV4).IsErr())
+			t.assert(FromDecimal("-1", FamilyV4).IsErr())
+		})
+		t.Run("test_base85", func(t *MyTesting) {
+			// the example of RFC 1924
+			str, err := Parse("1080::8:800:200c:417a").Unwrap().To_base85()
+			t.assert(err == nil)
+			t.assert_string("4)+k&C#VzJ4br>0wv%Yp", str)
+			t.assert_string("1080::8:800:200c:417a/128", FromBase85("4)+k&C#VzJ4br>0wv%Yp").Unwrap().To_string())
+			str, _ = Parse("::").Unwrap().To_base85()
+			t.assert_string("00000000000000000000", str)
+			str, _ = Parse("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff").Unwrap().To_base85()
+			t.assert_string("=r54lj&NUUO~Hi%c2ym0", str)
+			_, err = Parse("10.0.0.1").Unwrap().To_base85()
+			t.assert(errors.Is(err, ErrConversion))
+			_, err = FromBase85E("4)+k&C#VzJ4br>0wv%Y")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = FromBase85E("4)+k&C#VzJ4br>0wv%Y\"")
+			t.assert_int(19, parse_error_of(err).Offset)
+			_, err = FromBase85E("~~~~~~~~~~~~~~~~~~~~")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+		})
+		t.Run("test_ipv6_literal", func(t *MyTesting) {
+			str, err := Parse("2001:db8::1").Unwrap().To_ipv6_literal()
+			t.assert(err == nil)
+			t.assert_string("2001-db8--1.ipv6-literal.net", str)
+			str, _ = Parse("fe80::1%4").Unwrap().To_ipv6_literal()
+			t.assert_string("fe80--1s4.ipv6-literal.net", str)
+			str, _ = Parse("::").Unwrap().To_ipv6_literal()
+			t.assert_string("--.ipv6-literal.net", str)
+			_, err = Parse("10.0.0.1").Unwrap().To_ipv6_literal()
+			t.assert(errors.Is(err, ErrConversion))
+
+			t.assert_string("2001:db8::1/128", FromIpv6Literal("2001-db8--1.ipv6-literal.net").Unwrap().To_string())
+			t.assert_string("fe80::1%4/128", FromIpv6Literal("fe80--1s4.IPv6-Literal.NET.").Unwrap().To_string())
+			t.assert_string("fe80::1%eth0/128", FromIpv6Literal("fe80--1seth0.ipv6-literal.net").Unwrap().To_string())
+			t.assert_string("::/128", FromIpv6Literal("--.ipv6-literal.net").Unwrap().To_string())
+		})
+		t.Run("test_ipv6_literal_errors", func(t *MyTesting) {
+			_, err := FromIpv6LiteralE("2001-db8--1.example.net")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = FromIpv6LiteralE("2001-db8--g.ipv6-literal.net")
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(10, parse_error_of(err).Offset)
+			_, err = FromIpv6LiteralE("fe80--1s.ipv6-literal.net")
+			t.assert(errors.Is(err, ErrBadZone))
+			_, err = FromIpv6LiteralE("2001:db8::1.ipv6-literal.net")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = FromIpv6LiteralE(".ipv6-literal.net")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+		})
+	})
+}
@@ -0,0 +1,172 @@
+package ipaddress
+
+import "strconv"
+import "strings"
+
+// /  Endpoint is a host address together with a port, as
+// /  written in "10.0.0.1:8080" or "[2001:db8::1%eth0]:443":
+// /
+// /    ep, _ := ParseEndpoint("[fe80::1%eth0]:443")
+// /
+// /    ep.IP.Zone
+// /      ///  "eth0"
+// /    ep.Port
+// /      ///  443
+// /    ep.To_url_host()
+// /      ///  "[fe80::1%25eth0]:443"
+// /
+// /  Like IPAddress an Endpoint is a plain value which can be
+// /  compared with == and used as map key.
+// /
+type Endpoint struct {
+	IP   IPAddress
+	Port uint16
+}
+
+// /  Creates the endpoint of ip and port, the prefix of ip is
+// /  ignored
+// /
+func EndpointNew(ip *IPAddress, port uint16) *Endpoint {
+	return &Endpoint{host_of(ip), port}
+}
+
+// /  Parses "host:port" where an IPv6 host has to be in
+// /  brackets. The zone may be written as %eth0 or in the URL
+// /  form %25eth0:
+// /
+// /    ParseEndpoint("10.0.0.1:8080")
+// /    ParseEndpoint("[2001:db8::1]:443")
+// /    ParseEndpoint("[fe80::1%25eth0]:443")
+// /
+// /  Errors unwrap to ErrInvalidAddress for a malformed host
+// /  part, ErrPrefixRange if the host has a prefix and ErrBadPort
+// /  for a missing or out of range port.
+// /
+func ParseEndpoint(str string, opts ...ParseOptions) (*Endpoint, error) {
+	var host string
+	host_ofs := 0
+	port_ofs := 0
+	if strings.HasPrefix(str, "[") {
+		end := strings.IndexByte(str, ']')
+		if end < 0 {
+			return nil, new_parse_error(ErrInvalidAddress, str, len(str), "missing ]")
+		}
+		host = str[1:end]
+		host_ofs = 1
+		port_ofs = end + 1
+		if !strings.Contains(host, ":") {
+			return nil, new_parse_error(ErrInvalidAddress, str, host_ofs, "only IPv6 is written in brackets")
+		}
+	} else {
+		colon := strings.LastIndexByte(str, ':')
+		if colon < 0 {
+			return nil, new_parse_error(ErrBadPort, str, len(str), "missing port")
+		}
+		host = str[:colon]
+		port_ofs = colon
+		if strings.Contains(host, ":") {
+			return nil, new_parse_error(ErrInvalidAddress, str, 0, "IPv6 needs brackets in an endpoint")
+		}
+	}
+	if !strings.HasPrefix(str[port_ofs:], ":") {
+		return nil, new_parse_error(ErrBadPort, str, port_ofs, "missing port")
+	}
+	port, perr := parse_port(str, port_ofs+1)
+	if perr != nil {
+		return nil, perr
+	}
+	ip, err := ParseE(host, opts...)
+	if err != nil {
+		return nil, err.(*ParseError).rebase(str, host_ofs)
+	}
+	if ip.Prefix.Num != ip.Ip_bits.Bits || strings.Contains(host, "/") {
+		return nil, new_parse_error(ErrPrefixRange, str, host_ofs, "endpoint must not have a prefix")
+	}
+	return &Endpoint{*ip, port}, nil
+}
+
+func parse_port(str string, ofs int) (uint16, *ParseError) {
+	digits := str[ofs:]
+	if len(digits) == 0 || len(digits) > 5 || strings.TrimLeft(digits, "0123456789") != "" {
+		return 0, new_parse_error(ErrBadPort, str, ofs, "illegal port %q", digits)
+	}
+	port, err := strconv.ParseUint(digits, 10, 16)
+	if err != nil {
+		return 0, new_parse_error(ErrBadPort, str, ofs, "port out of range %q", digits)
+	}
+	return uint16(port), nil
+}
+
+// /  Returns the host as written in URLs, IPv6 in brackets and
+// /  the zone escaped as %25 (RFC 6874)
+// /
+// /    ip = IPAddress("fe80::1%eth0/64")
+// /
+// /    ip.To_url_host()
+// /      ///  "[fe80::1%25eth0]"
+// /
+func (self *IPAddress) To_url_host() string {
+	if self.Is_ipv4() {
+		return self.To_s()
+	}
+	ret := "[" + self.Without_zone().To_s()
+	if self.Zone != "" {
+		ret += "%25" + self.Zone
+	}
+	return ret + "]"
+}
+
+// /  Returns the endpoint as "10.0.0.1:8080" or
+// /  "[fe80::1%eth0]:443"
+// /
+func (self *Endpoint) To_s() string {
+	port := strconv.Itoa(int(self.Port))
+	if self.IP.Is_ipv4() {
+		return self.IP.To_s() + ":" + port
+	}
+	return "[" + self.IP.To_s() + "]:" + port
+}
+
+func (self *Endpoint) String() string {
+	return self.To_s()
+}
+
+// /  Returns the endpoint as URL host, like "[fe80::1%25eth0]:443"
+// /
+func (self *Endpoint) To_url_host() string {
+	return self.IP.To_url_host() + ":" + strconv.Itoa(int(self.Port))
+}
+
+// /  Orders by address like IPAddress.Cmp, then by port
+// /
+func (self *Endpoint) Cmp(oth *Endpoint) int {
+	if cmp := self.IP.Cmp(&oth.IP); cmp != 0 {
+		return cmp
+	}
+	if self.Port < oth.Port {
+		return -1
+	} else if self.Port > oth.Port {
+		return 1
+	}
+	return 0
+}
+
+func (self Endpoint) MarshalText() ([]byte, error) {
+	if self.IP.Ip_bits == nil {
+		return []byte{}, nil
+	}
+	return []byte(self.To_s()), nil
+}
+
+func (self *Endpoint) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*self = Endpoint{}
+		return nil
+	}
+	ep, err := ParseEndpoint(string(text))
+	if err != nil {
+		return err
+	}
+	*self = *ep
+	return nil
+}
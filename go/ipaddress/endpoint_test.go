@@ -0,0 +1,98 @@
+package ipaddress
+
+import "encoding/json"
+import "errors"
+import "slices"
+import "testing"
+
+func must_endpoint(str string) *Endpoint {
+	ret, err := ParseEndpoint(str)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+func TestEndpoint(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestEndpoint", func(t *MyTesting) {
+		t.Run("test_parse", func(t *MyTesting) {
+			ep := must_endpoint("10.0.0.1:8080")
+			t.assert_string("10.0.0.1/32", ep.IP.To_string())
+			t.assert_int(8080, int(ep.Port))
+			t.assert_string("10.0.0.1:8080", ep.To_s())
+			ep = must_endpoint("[2001:db8::1]:443")
+			t.assert_string("2001:db8::1/128", ep.IP.To_string())
+			t.assert_string("[2001:db8::1]:443", ep.String())
+			ep = must_endpoint("[fe80::1%eth0]:443")
+			t.assert_string("eth0", ep.IP.Zone)
+			t.assert_string("[fe80::1%eth0]:443", ep.To_s())
+			t.assert(*ep == *must_endpoint("[fe80::1%25eth0]:443"))
+			t.assert_string("[::ffff:a00:1]:0", must_endpoint("[::ffff:10.0.0.1]:0").To_s())
+			t.assert_int(65535, int(must_endpoint("10.0.0.1:65535").Port))
+		})
+		t.Run("test_parse_errors", func(t *MyTesting) {
+			_, err := ParseEndpoint("10.0.0.1")
+			t.assert(errors.Is(err, ErrBadPort))
+			_, err = ParseEndpoint("10.0.0.1:65536")
+			t.assert(errors.Is(err, ErrBadPort))
+			t.assert_int(9, parse_error_of(err).Offset)
+			_, err = ParseEndpoint("10.0.0.1:+80")
+			t.assert(errors.Is(err, ErrBadPort))
+			_, err = ParseEndpoint("[2001:db8::1]")
+			t.assert(errors.Is(err, ErrBadPort))
+			_, err = ParseEndpoint("[2001:db8::1]443")
+			t.assert(errors.Is(err, ErrBadPort))
+			_, err = ParseEndpoint("2001:db8::1:443")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseEndpoint("[2001:db8::1:443")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseEndpoint("[10.0.0.1]:80")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseEndpoint("10.0.0.256:80")
+			t.assert(errors.Is(err, ErrBadOctet))
+			_, err = ParseEndpoint("[2001:db8::g]:80")
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(11, parse_error_of(err).Offset)
+			_, err = ParseEndpoint("10.0.0.0/8:80")
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = ParseEndpoint("10.0.0.1/32:80")
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+		t.Run("test_url_host", func(t *MyTesting) {
+			t.assert_string("[fe80::1%25eth0]:443", must_endpoint("[fe80::1%eth0]:443").To_url_host())
+			t.assert_string("10.0.0.1:80", must_endpoint("10.0.0.1:80").To_url_host())
+			t.assert_string("[fe80::1%25eth0]", Parse("fe80::1%eth0/64").Unwrap().To_url_host())
+			t.assert_string("[2001:db8::1]", Parse("2001:db8::1").Unwrap().To_url_host())
+			t.assert_string("10.0.0.1", Parse("10.0.0.1/8").Unwrap().To_url_host())
+		})
+		t.Run("test_new", func(t *MyTesting) {
+			ep := EndpointNew(Parse("10.0.0.1/8").Unwrap(), 53)
+			t.assert(*ep == *must_endpoint("10.0.0.1:53"))
+		})
+		t.Run("test_cmp", func(t *MyTesting) {
+			eps := []*Endpoint{
+				must_endpoint("[2001:db8::1]:80"),
+				must_endpoint("10.0.0.2:80"),
+				must_endpoint("10.0.0.1:443"),
+				must_endpoint("10.0.0.1:80"),
+			}
+			slices.SortFunc(eps, func(a, b *Endpoint) int { return a.Cmp(b) })
+			strs := make([]string, len(eps))
+			for i, ep := range eps {
+				strs[i] = ep.To_s()
+			}
+			t.assert_string_array([]string{"10.0.0.1:80", "10.0.0.1:443", "10.0.0.2:80", "[2001:db8::1]:80"}, strs)
+			t.assert_int(0, must_endpoint("10.0.0.1:80").Cmp(must_endpoint("10.0.0.1:80")))
+		})
+		t.Run("test_marshal", func(t *MyTesting) {
+			data, err := json.Marshal(map[string]Endpoint{"a": *must_endpoint("[fe80::1%eth0]:443")})
+			t.assert(err == nil)
+			t.assert_string(`{"a":"[fe80::1%eth0]:443"}`, string(data))
+			var out map[string]Endpoint
+			t.assert(json.Unmarshal(data, &out) == nil)
+			t.assert(out["a"] == *must_endpoint("[fe80::1%eth0]:443"))
+			t.assert(json.Unmarshal([]byte(`{"a":"10.0.0.1"}`), &out) != nil)
+		})
+	})
+}
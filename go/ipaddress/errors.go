@@ -0,0 +1,68 @@
+package ipaddress
+
+import (
+	"errors"
+	"fmt"
+)
+
+// /  The kinds of errors reported by the parsers. A ParseError
+// /  unwraps to one of these, so callers can test for them with
+// /  errors.Is:
+// /
+// /    _, err := ParseE("10.0.0.256")
+// /    errors.Is(err, ErrBadOctet)
+// /      ///  true
+// /
+var (
+	ErrInvalidAddress     = errors.New("invalid address")
+	ErrBadOctet           = errors.New("bad octet")
+	ErrTooManyDoubleColon = errors.New("too many ::")
+	ErrPrefixRange        = errors.New("prefix out of range")
+	ErrBadNetmask         = errors.New("bad netmask")
+	ErrNotMapped          = errors.New("not mapped")
+	ErrConversion         = errors.New("conversion not possible")
+	ErrBadZone            = errors.New("bad zone")
+	ErrBadPort            = errors.New("bad port")
+	ErrBadMac             = errors.New("bad MAC address")
+	// also matches ErrBadNetmask
+	ErrNonContiguousMask = fmt.Errorf("non-contiguous mask: %w", ErrBadNetmask)
+)
+
+// /  ParseError describes why and where an input could not be
+// /  parsed. Offset is the byte offset into Input of the offending
+// /  character, Kind is one of the Err* values above.
+// /
+// /    _, err := ParseE("10.0.0.256")
+// /    var perr *ParseError
+// /    errors.As(err, &perr)
+// /    perr.Offset
+// /      ///  7
+// /
+type ParseError struct {
+	Input  string
+	Offset int
+	Kind   error
+	Msg    string
+}
+
+func new_parse_error(kind error, input string, offset int, format string, args ...interface{}) *ParseError {
+	return &ParseError{input, offset, kind, fmt.Sprintf(format, args...)}
+}
+
+func (self *ParseError) Error() string {
+	if self.Msg == "" {
+		return fmt.Sprintf("%s at offset %d in %q", self.Kind, self.Offset, self.Input)
+	}
+	return fmt.Sprintf("%s at offset %d in %q: %s", self.Kind, self.Offset, self.Input, self.Msg)
+}
+
+func (self *ParseError) Unwrap() error {
+	return self.Kind
+}
+
+// /  Moves an error found in a part of the input to the
+// /  position of that part within the whole input
+// /
+func (self *ParseError) rebase(input string, offset int) *ParseError {
+	return &ParseError{input, self.Offset + offset, self.Kind, self.Msg}
+}
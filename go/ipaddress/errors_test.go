@@ -0,0 +1,93 @@
+package ipaddress
+
+import "errors"
+import "testing"
+
+func parse_error_of(err error) *ParseError {
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		return nil
+	}
+	return perr
+}
+
+func TestErrors(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestErrors", func(t *MyTesting) {
+		t.Run("test_bad_octet", func(t *MyTesting) {
+			ip, err := ParseE("10.0.0.256")
+			t.assert(ip == nil)
+			t.assert(errors.Is(err, ErrBadOctet))
+			perr := parse_error_of(err)
+			t.assert(perr != nil)
+			t.assert_int(7, perr.Offset)
+			t.assert_string("10.0.0.256", perr.Input)
+		})
+		t.Run("test_bad_hex_group", func(t *MyTesting) {
+			_, err := ParseE("2001:db8::xyz")
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(10, parse_error_of(err).Offset)
+		})
+		t.Run("test_too_many_double_colon", func(t *MyTesting) {
+			_, err := ParseE("1::2::3")
+			t.assert(errors.Is(err, ErrTooManyDoubleColon))
+			t.assert_int(4, parse_error_of(err).Offset)
+		})
+		t.Run("test_invalid_address", func(t *MyTesting) {
+			_, err := ParseE("hello")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseE("1.2.3.4.5")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			t.assert_int(8, parse_error_of(err).Offset)
+		})
+		t.Run("test_prefix_range", func(t *MyTesting) {
+			_, err := ParseE("10.0.0.1/33")
+			t.assert(errors.Is(err, ErrPrefixRange))
+			t.assert_int(9, parse_error_of(err).Offset)
+			_, err = ParseE("::ffff:10.0.0.1/128")
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Prefix32NewE(33)
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Prefix128NewE(129)
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Parse("10.0.0.0/8").Unwrap().SubnetE(7)
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Parse("10.0.0.0/8").Unwrap().SupernetE(9)
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+		t.Run("test_bad_netmask", func(t *MyTesting) {
+			_, err := ParseE("10.0.0.1/255.0.255.0")
+			t.assert(errors.Is(err, ErrBadNetmask))
+			t.assert_int(9, parse_error_of(err).Offset)
+			_, err = ParseE("2001:db8::1/abc")
+			t.assert(errors.Is(err, ErrBadNetmask))
+		})
+		t.Run("test_not_mapped", func(t *MyTesting) {
+			_, err := Ipv6MappedNewE("10.0.0.1")
+			t.assert(errors.Is(err, ErrNotMapped))
+			_, err = Ipv6MappedNewE("1::ffff:10.0.0.1")
+			t.assert(errors.Is(err, ErrNotMapped))
+		})
+		t.Run("test_result_wrapper", func(t *MyTesting) {
+			res := Parse("10.0.0.256")
+			t.assert(res.IsErr())
+			t.assert_string(
+				"bad octet at offset 7 in \"10.0.0.256\": IP items has to lower than 256 \"256\"",
+				*res.UnwrapErr())
+			pres := Prefix32New(33)
+			t.assert(pres.IsErr())
+			perr := pres.(*PrefixError)
+			t.assert_string(*perr.UnwrapErr(), *perr.Err)
+			t.assert(errors.Is(perr.As_error(), ErrPrefixRange))
+			t.assert_string(perr.As_error().Error(), *perr.Err)
+		})
+		t.Run("test_ok", func(t *MyTesting) {
+			ip, err := ParseE("172.16.10.1/24")
+			t.assert(err == nil)
+			t.assert_string("172.16.10.1/24", ip.To_string())
+			ip, err = ParseE("::ffff:172.16.10.1/24")
+			t.assert(err == nil)
+			t.assert_string("::ffff:ac10:a01/120", ip.To_string())
+		})
+	})
+}
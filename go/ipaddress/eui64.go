@@ -0,0 +1,196 @@
+package ipaddress
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// /  Parses an EUI-48 (MAC) or EUI-64 address, the bytes written
+// /  as hex and separated by colons or dashes, in groups of four
+// /  separated by dots or without any separator:
+// /
+// /    ParseMac("00:1b:63:84:45:e6")
+// /    ParseMac("00-1B-63-84-45-E6")
+// /    ParseMac("001b.6384.45e6")
+// /    ParseMac("001b638445e6")
+// /    ParseMac("00:1b:63:ff:fe:84:45:e6")
+// /
+// /  Errors unwrap to ErrBadMac.
+// /
+func ParseMac(str string) (net.HardwareAddr, error) {
+	sep := strings.IndexAny(str, ":-.")
+	group := 2
+	parts := []string{str}
+	if sep >= 0 {
+		if str[sep] == '.' {
+			group = 4
+		}
+		parts = strings.Split(str, str[sep:sep+1])
+	} else if len(str) != 12 && len(str) != 16 {
+		return nil, new_parse_error(ErrBadMac, str, 0, "needs 12 or 16 hex digits")
+	}
+	ret := net.HardwareAddr{}
+	ofs := 0
+	for _, part := range parts {
+		if sep >= 0 && len(part) != group {
+			return nil, new_parse_error(ErrBadMac, str, ofs, "groups need %d hex digits", group)
+		}
+		for i := 0; i < len(part); i += 2 {
+			b, err := strconv.ParseUint(part[i:i+2], 16, 8)
+			if err != nil {
+				return nil, new_parse_error(ErrBadMac, str, ofs+i, "bad hex digit")
+			}
+			ret = append(ret, byte(b))
+		}
+		ofs += len(part) + 1
+	}
+	if len(ret) != 6 && len(ret) != 8 {
+		return nil, new_parse_error(ErrBadMac, str, 0, "needs 6 or 8 bytes, got %d", len(ret))
+	}
+	return ret, nil
+}
+
+// /  Returns the modified EUI-64 interface identifier (RFC 4291
+// /  appendix A) of an EUI-48 or EUI-64 address: ff:fe is
+// /  inserted into an EUI-48 and the universal/local bit is
+// /  inverted
+// /
+// /    Eui64_iid(ParseMac("00:1b:63:84:45:e6"))
+// /      ///  0x021b63fffe8445e6
+// /
+func Eui64_iid(mac net.HardwareAddr) (uint64, error) {
+	var eui []byte
+	switch len(mac) {
+	case 6:
+		eui = []byte{mac[0], mac[1], mac[2], 0xff, 0xfe, mac[3], mac[4], mac[5]}
+	case 8:
+		eui = []byte(mac)
+	default:
+		return 0, new_parse_error(ErrBadMac, mac.String(), 0, "needs 6 or 8 bytes, got %d", len(mac))
+	}
+	return binary.BigEndian.Uint64(eui) ^ 0x0200000000000000, nil
+}
+
+// /  Returns the interface identifier, the low 64 bits of an
+// /  IPv6 address
+// /
+func (self *IPAddress) Iid() uint64 {
+	return self.Host_address.Lo
+}
+
+// /  Combines the /64 (or shorter) prefix self with the
+// /  interface identifier to a host address
+// /
+// /    prefix = IPAddress("2001:db8::/64")
+// /
+// /    prefix.With_iid(0x021b63fffe8445e6).To_s()
+// /      ///  "2001:db8::21b:63ff:fe84:45e6"
+// /
+func (self *IPAddress) With_iid(iid uint64) (*IPAddress, error) {
+	if !self.Is_ipv6() || self.Prefix.Num > 64 {
+		return nil, new_parse_error(ErrPrefixRange, self.To_string(), 0,
+			"the prefix must be IPv6 with length up to 64")
+	}
+	return Ipv6FromU128(U128{self.Network().Host_address.Hi, iid}, 128).Unwrap(), nil
+}
+
+// /  Returns the SLAAC address of the MAC within the prefix self
+// /
+// /    prefix = IPAddress("fe80::/64")
+// /
+// /    prefix.Slaac_eui64(ParseMac("00:1b:63:84:45:e6")).To_s()
+// /      ///  "fe80::21b:63ff:fe84:45e6"
+// /
+func (self *IPAddress) Slaac_eui64(mac net.HardwareAddr) (*IPAddress, error) {
+	iid, err := Eui64_iid(mac)
+	if err != nil {
+		return nil, err
+	}
+	return self.With_iid(iid)
+}
+
+// /  Checks if the interface identifier is derived from an
+// /  EUI-48, which is marked by ff:fe in its middle
+// /
+func (self *IPAddress) Is_eui64() bool {
+	return self.Is_ipv6() && (self.Host_address.Lo>>24)&0xffff == 0xfffe
+}
+
+// /  Returns the MAC an EUI-64 based interface identifier was
+// /  derived from
+// /
+// /    ip = IPAddress("fe80::21b:63ff:fe84:45e6")
+// /
+// /    ip.Mac().String()
+// /      ///  "00:1b:63:84:45:e6"
+// /
+func (self *IPAddress) Mac() (net.HardwareAddr, error) {
+	if !self.Is_eui64() {
+		return nil, new_parse_error(ErrNotMapped, self.To_string(), 0, "not an EUI-64 interface identifier")
+	}
+	iid := self.Host_address.Lo ^ 0x0200000000000000
+	return net.HardwareAddr{byte(iid >> 56), byte(iid >> 48), byte(iid >> 40),
+		byte(iid >> 16), byte(iid >> 8), byte(iid)}, nil
+}
+
+// interface identifiers reserved by RFC 5453 and the IANA
+// registry: the subnet-router anycast, the IANA Ethernet block
+// 0200:5eff:fe00:0-0200:5eff:feff:ffff and the reserved subnet
+// anycast addresses fdff:ffff:ffff:ff80-fdff:ffff:ffff:ffff
+func is_reserved_iid(iid uint64) bool {
+	return iid == 0 ||
+		iid>>24 == 0x02005efffe ||
+		iid>>7 == 0xfdffffffffffff80>>7
+}
+
+// /  The number of retries of Stable_opaque when the identifier
+// /  is reserved, IDGEN_RETRIES of RFC 7217
+// /
+const Idgen_retries = 3
+
+// /  Returns the stable and semantically opaque address of
+// /  RFC 7217 within the prefix self. The interface identifier
+// /  is taken from the least significant 64 bits of
+// /
+// /    RID = HMAC-SHA256(secret_key, prefix | net_iface | network_id | dad_counter)
+// /
+// /  with the upper 64 bits of the prefix network, the network_id
+// /  may be empty. Reserved identifiers (RFC 5453) are skipped by
+// /  incrementing the DAD counter up to Idgen_retries times,
+// /  after that an error which unwraps to ErrConversion is
+// /  returned.
+// /
+// /    prefix = IPAddress("2001:db8:1:2::/64")
+// /
+// /    prefix.Stable_opaque([]byte("secret"), "eth0", nil, 0).To_s()
+// /      ///  "2001:db8:1:2:46b3:27e6:3656:8306"
+// /
+func (self *IPAddress) Stable_opaque(secret_key []byte, net_iface string, network_id []byte, dad_counter uint8) (*IPAddress, error) {
+	if !self.Is_ipv6() || self.Prefix.Num > 64 {
+		return nil, new_parse_error(ErrPrefixRange, self.To_string(), 0,
+			"the prefix must be IPv6 with length up to 64")
+	}
+	prefix := binary.BigEndian.AppendUint64(nil, self.Network().Host_address.Hi)
+	for retry := 0; retry <= Idgen_retries && int(dad_counter)+retry <= 0xff; retry++ {
+		iid := stable_opaque_iid(secret_key, prefix, net_iface, network_id, dad_counter+uint8(retry))
+		if !is_reserved_iid(iid) {
+			return self.With_iid(iid)
+		}
+	}
+	return nil, new_parse_error(ErrConversion, self.To_string(), 0,
+		"no unreserved interface identifier within %d retries", Idgen_retries)
+}
+
+func stable_opaque_iid(secret_key []byte, prefix []byte, net_iface string, network_id []byte, dad_counter uint8) uint64 {
+	mac := hmac.New(sha256.New, secret_key)
+	mac.Write(prefix)
+	mac.Write([]byte(net_iface))
+	mac.Write(network_id)
+	mac.Write([]byte{dad_counter})
+	rid := mac.Sum(nil)
+	return binary.BigEndian.Uint64(rid[len(rid)-8:])
+}
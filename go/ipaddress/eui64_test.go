@@ -0,0 +1,125 @@
+package ipaddress
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEui64(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestEui64", func(t *MyTesting) {
+		t.Run("test_parse_mac", func(t *MyTesting) {
+			for _, str := range []string{"00:1b:63:84:45:e6", "00-1B-63-84-45-E6",
+				"001b.6384.45e6", "001b638445e6"} {
+				mac, err := ParseMac(str)
+				t.assert(err == nil)
+				t.assert_string("00:1b:63:84:45:e6", mac.String())
+			}
+			mac, err := ParseMac("00:1b:63:ff:fe:84:45:e6")
+			t.assert(err == nil)
+			t.assert_int(8, len(mac))
+			mac, err = ParseMac("001b63fffe8445e6")
+			t.assert(err == nil)
+			t.assert_string("00:1b:63:ff:fe:84:45:e6", mac.String())
+			for str, ofs := range map[string]int{
+				"00:1b:63:84:45":    0,
+				"00:1b:63:84:45:g6": 15,
+				"00:1b:63:84:45-e6": 12,
+				"0:1b:63:84:45:e6":  0,
+				"001b.6384.45e":     10,
+				"001b638445e":       0,
+				"":                  0,
+			} {
+				_, err := ParseMac(str)
+				t.assert(errors.Is(err, ErrBadMac))
+				t.assert_int(ofs, parse_error_of(err).Offset)
+			}
+		})
+		t.Run("test_eui64_iid", func(t *MyTesting) {
+			mac, _ := ParseMac("00:1b:63:84:45:e6")
+			iid, err := Eui64_iid(mac)
+			t.assert(err == nil)
+			t.assert(iid == 0x021b63fffe8445e6)
+			// a locally administered MAC loses the bit
+			mac, _ = ParseMac("02:00:5e:10:00:01")
+			iid, _ = Eui64_iid(mac)
+			t.assert(iid == 0x00005efffe100001)
+			mac, _ = ParseMac("00:1b:63:00:00:84:45:e6")
+			iid, _ = Eui64_iid(mac)
+			t.assert(iid == 0x021b6300008445e6)
+			_, err = Eui64_iid([]byte{1, 2, 3})
+			t.assert(errors.Is(err, ErrBadMac))
+		})
+		t.Run("test_slaac", func(t *MyTesting) {
+			mac, _ := ParseMac("00:1b:63:84:45:e6")
+			ip, err := Parse("fe80::/64").Unwrap().Slaac_eui64(mac)
+			t.assert(err == nil)
+			t.assert_string("fe80::21b:63ff:fe84:45e6/128", ip.To_string())
+			ip, err = Parse("2001:db8:1:2::1/64").Unwrap().With_iid(0x021b63fffe8445e6)
+			t.assert(err == nil)
+			t.assert_string("2001:db8:1:2:21b:63ff:fe84:45e6", ip.To_s())
+			t.assert(ip.Iid() == 0x021b63fffe8445e6)
+			t.assert(ip.Is_eui64())
+			back, err := ip.Mac()
+			t.assert(err == nil)
+			t.assert_string("00:1b:63:84:45:e6", back.String())
+			_, err = Parse("2001:db8::/96").Unwrap().With_iid(1)
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Parse("10.0.0.0/8").Unwrap().Slaac_eui64(mac)
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Parse("2001:db8::1").Unwrap().Mac()
+			t.assert(errors.Is(err, ErrNotMapped))
+		})
+		t.Run("test_stable_opaque", func(t *MyTesting) {
+			prefix := Parse("2001:db8:1:2::/64").Unwrap()
+			secret := []byte("secret")
+			a, err := prefix.Stable_opaque(secret, "eth0", nil, 0)
+			t.assert(err == nil)
+			t.assert(prefix.Includes(a))
+			t.assert_uint8(128, a.Prefix.Num)
+			b, _ := Parse("2001:db8:1:2::1/64").Unwrap().Stable_opaque(secret, "eth0", nil, 0)
+			t.assert(*a == *b)
+			for _, c := range []*IPAddress{
+				must_ip(prefix.Stable_opaque(secret, "eth1", nil, 0)),
+				must_ip(prefix.Stable_opaque(secret, "eth0", nil, 1)),
+				must_ip(prefix.Stable_opaque(secret, "eth0", []byte("ssid"), 0)),
+				must_ip(prefix.Stable_opaque([]byte("other"), "eth0", nil, 0)),
+				must_ip(Parse("2001:db8:1:3::/64").Unwrap().Stable_opaque(secret, "eth0", nil, 0)),
+			} {
+				t.assert(a.Iid() != c.Iid())
+				t.assert(!is_reserved_iid(c.Iid()))
+			}
+			_, err = Parse("2001:db8::/80").Unwrap().Stable_opaque(secret, "eth0", nil, 0)
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+		t.Run("test_stable_opaque_known_answer", func(t *MyTesting) {
+			prefix := Parse("2001:db8:1:2::/64").Unwrap()
+			ip, err := prefix.Stable_opaque([]byte("secret"), "eth0", nil, 0)
+			t.assert(err == nil)
+			t.assert_string("2001:db8:1:2:46b3:27e6:3656:8306", ip.To_s())
+			ip, err = prefix.Stable_opaque([]byte("secret"), "eth0", []byte("ssid"), 1)
+			t.assert(err == nil)
+			t.assert_string("2001:db8:1:2:e3da:634f:34ab:25fe", ip.To_s())
+		})
+		t.Run("test_reserved_iid", func(t *MyTesting) {
+			t.assert(is_reserved_iid(0))
+			t.assert(is_reserved_iid(0xfdffffffffffff80))
+			t.assert(is_reserved_iid(0xfdffffffffffffff))
+			t.assert(!is_reserved_iid(0xfdffffffffffff7f))
+			t.assert(!is_reserved_iid(0x00005efec0000221))
+			t.assert(!is_reserved_iid(0x02005efe08080808))
+			t.assert(is_reserved_iid(0x02005efffe000000))
+			t.assert(is_reserved_iid(0x02005efffe005213))
+			t.assert(is_reserved_iid(0x02005efffeffffff))
+			t.assert(!is_reserved_iid(0x02005effff000000))
+			t.assert(!is_reserved_iid(0x021b63fffe8445e6))
+		})
+	})
+}
+
+func must_ip(ip *IPAddress, err error) *IPAddress {
+	if err != nil {
+		panic(err)
+	}
+	return ip
+}
@@ -0,0 +1,209 @@
+package ipaddress
+
+import "bufio"
+import "io"
+import "iter"
+import "strings"
+
+// /  An address found by Extract. Text is the token as it was
+// /  found in the input, Offset the byte offset of its first
+// /  character.
+// /
+type Match struct {
+	IP     *IPAddress
+	Text   string
+	Offset int64
+}
+
+// /  Finds every IPv4 and IPv6 address and CIDR in a text stream,
+// /  like log lines:
+// /
+// /    str = "foo 172.16.10.1 bar [2001:db8::1]:443 baz 10.0.0.0/8"
+// /
+// /    Extract(strings.NewReader(str))
+// /      ///  [{172.16.10.1/32 "172.16.10.1" 4}
+// /      ///   {2001:db8::1/128 "[2001:db8::1]" 20}
+// /      ///   {10.0.0.0/8 "10.0.0.0/8" 42}]
+// /
+// /  IPv6 may be compressed, in mixed notation, bracketed and
+// /  zoned. IPv4 has to be a strict dotted quad, so version strings
+// /  like "1.2.3.4.5" are not matched, and MAC addresses are not
+// /  taken for IPv6. An address must not be glued to letters or
+// /  digits, "a port like 10.0.0.1:80" finds 10.0.0.1.
+// /
+func Extract(r io.Reader) ([]*Match, error) {
+	ret := []*Match{}
+	for m, err := range Extract_seq(r) {
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, m)
+	}
+	return ret, nil
+}
+
+// /  Like Extract but yields the matches while reading, a read
+// /  error is yielded with a nil match and ends the sequence
+// /
+func Extract_seq(r io.Reader) iter.Seq2[*Match, error] {
+	return func(yield func(*Match, error) bool) {
+		reader := bufio.NewReader(r)
+		offset := int64(0)
+		for {
+			line, err := reader.ReadString('\n')
+			for _, m := range extract_line(line, offset) {
+				if !yield(m, nil) {
+					return
+				}
+			}
+			offset += int64(len(line))
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+func is_word_char(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func is_hex_char(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func is_addr_char(c byte) bool {
+	return is_hex_char(c) || c == '.' || c == ':'
+}
+
+func is_zone_char(c byte) bool {
+	return is_word_char(c) || c == '.' || c == '-'
+}
+
+func extract_line(line string, base int64) []*Match {
+	ret := []*Match{}
+	i := 0
+	for i < len(line) {
+		c := line[i]
+		if !(is_addr_char(c) || c == '[') || (i > 0 && (is_word_char(line[i-1]) || line[i-1] == '.' || line[i-1] == ':')) {
+			i++
+			continue
+		}
+		m, end := extract_at(line, i)
+		if m != nil {
+			m.Offset += base
+			ret = append(ret, m)
+		}
+		if end <= i {
+			end = i + 1
+		}
+		i = end
+	}
+	return ret
+}
+
+// tries to read an address which starts at line[start], returns
+// the match or nil and the position to continue scanning
+func extract_at(line string, start int) (*Match, int) {
+	i := start
+	bracket := line[i] == '['
+	if bracket {
+		i++
+	}
+	core_start := i
+	for i < len(line) && is_addr_char(line[i]) {
+		i++
+	}
+	core_end := i
+	// sentence punctuation after an address
+	for core_end > core_start && (line[core_end-1] == '.' ||
+		(line[core_end-1] == ':' && !strings.HasSuffix(line[core_start:core_end], "::"))) {
+		core_end--
+	}
+	core := line[core_start:core_end]
+	if strings.Contains(core, ":") && strings.Contains(core, ".") {
+		// a port after an IPv4 address
+		if colon := strings.LastIndexByte(core, ':'); strings.Count(core, ":") == 1 && colon > 0 {
+			if _, err := split_to_u32_strict(core[:colon]); err == nil {
+				core_end = core_start + colon
+				core = core[:colon]
+			}
+		}
+	}
+	if !extract_plausible(core) {
+		return nil, i
+	}
+	end := core_end
+	if core_end == i && i < len(line) && line[i] == '%' && strings.Contains(core, ":") {
+		j := i + 1
+		for j < len(line) && is_zone_char(line[j]) {
+			j++
+		}
+		if j > i+1 {
+			end = j
+		}
+	}
+	if bracket {
+		if end >= len(line) || line[end] != ']' || !strings.Contains(core, ":") {
+			return nil, end
+		}
+		end++
+	}
+	addr_end := end
+	if end+1 < len(line) && line[end] == '/' && line[end+1] >= '0' && line[end+1] <= '9' {
+		j := end + 1
+		for j < len(line) && j < end+4 && line[j] >= '0' && line[j] <= '9' {
+			j++
+		}
+		if j >= len(line) || !is_word_char(line[j]) {
+			end = j
+		}
+	}
+	if end < len(line) && is_word_char(line[end]) {
+		return nil, end
+	}
+	addr := line[core_start:addr_end]
+	if bracket {
+		addr = line[core_start : addr_end-1]
+	}
+	strict := ParseOptions{Ipv4: Ipv4Strict}
+	ip, err := ParseE(addr+line[addr_end:end], strict)
+	if err != nil && end != addr_end {
+		// not a prefix, like a path "10.0.0.1/999"
+		end = addr_end
+		ip, err = ParseE(addr, strict)
+	}
+	if err != nil {
+		return nil, end
+	}
+	return &Match{ip, line[start:end], int64(start)}, end
+}
+
+// filters tokens which parse but are not meant as address
+func extract_plausible(core string) bool {
+	if !strings.Contains(core, ":") {
+		return strings.Count(core, ".") == 3
+	}
+	if !strings.ContainsAny(core, "0123456789abcdefABCDEF") {
+		// a lonely ::
+		return false
+	}
+	if !strings.Contains(core, "::") {
+		// MAC addresses and EUI-64 are groups of two digits
+		mac := true
+		for _, group := range strings.Split(core, ":") {
+			if len(group) != 2 {
+				mac = false
+				break
+			}
+		}
+		if mac {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package ipaddress
+
+import "errors"
+import "io"
+import "strings"
+import "testing"
+
+type failing_reader struct{}
+
+func (failing_reader) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func extract_strings(str string) []string {
+	matches, err := Extract(strings.NewReader(str))
+	if err != nil {
+		panic(err)
+	}
+	ret := make([]string, len(matches))
+	for i, m := range matches {
+		ret[i] = m.Text
+	}
+	return ret
+}
+
+func TestExtract(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestExtract", func(t *MyTesting) {
+		t.Run("test_offsets", func(t *MyTesting) {
+			str := "foo 172.16.10.1 bar [2001:db8::1]:443 baz 10.0.0.0/8\nnext 2001:db8::/32 end"
+			matches, err := Extract(strings.NewReader(str))
+			t.assert(err == nil)
+			t.assert_int(4, len(matches))
+			t.assert_string("172.16.10.1/32", matches[0].IP.To_string())
+			t.assert_int(4, int(matches[0].Offset))
+			t.assert_string("[2001:db8::1]", matches[1].Text)
+			t.assert_string("2001:db8::1/128", matches[1].IP.To_string())
+			t.assert_int(20, int(matches[1].Offset))
+			t.assert_string("10.0.0.0/8", matches[2].IP.To_string())
+			t.assert_int(42, int(matches[2].Offset))
+			t.assert_string("2001:db8::/32", matches[3].IP.To_string())
+			t.assert_int(58, int(matches[3].Offset))
+			for _, m := range matches {
+				t.assert_string(m.Text, str[m.Offset:m.Offset+int64(len(m.Text))])
+			}
+		})
+		t.Run("test_ipv6_forms", func(t *MyTesting) {
+			t.assert_string_array([]string{"::1", "fe80::1%eth0", "::ffff:10.0.0.1", "64:ff9b::192.0.2.33"},
+				extract_strings("lo ::1, link fe80::1%eth0; mapped ::ffff:10.0.0.1 nat64 64:ff9b::192.0.2.33."))
+			t.assert_string_array([]string{"2001:db8:0:0:1:0:0:1"},
+				extract_strings("full 2001:db8:0:0:1:0:0:1"))
+		})
+		t.Run("test_ports_and_punctuation", func(t *MyTesting) {
+			t.assert_string_array([]string{"10.0.0.1", "10.0.0.2"},
+				extract_strings("from 10.0.0.1:8080 to 10.0.0.2."))
+			t.assert_string_array([]string{"192.168.0.1"},
+				extract_strings("(192.168.0.1)"))
+			t.assert_string_array([]string{"10.0.0.1"},
+				extract_strings("path 10.0.0.1/999"))
+		})
+		t.Run("test_false_positives", func(t *MyTesting) {
+			t.assert_string_array([]string{}, extract_strings("version 1.2.3.4.5 released"))
+			t.assert_string_array([]string{}, extract_strings("mac 00:1a:2b:3c:4d:5e"))
+			t.assert_string_array([]string{}, extract_strings("bad 10.0.0.256 and 010.0.0.1"))
+			t.assert_string_array([]string{}, extract_strings("v1.2.3.4 host1.2.3.4 12:30 deadbeef ::"))
+			t.assert_string_array([]string{}, extract_strings("[10.0.0.1] 10.0.0.1abc"))
+		})
+		t.Run("test_read_error", func(t *MyTesting) {
+			matches, err := Extract(io.MultiReader(strings.NewReader("10.0.0.1\n"), failing_reader{}))
+			t.assert(err != nil)
+			t.assert_int(1, len(matches))
+			cnt := 0
+			for m, err := range Extract_seq(strings.NewReader("10.0.0.1 10.0.0.2 10.0.0.3")) {
+				t.assert(err == nil)
+				t.assert(m != nil)
+				cnt++
+				if cnt == 2 {
+					break
+				}
+			}
+			t.assert_int(2, cnt)
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package ipaddress
+
+import "fmt"
+import "strings"
+
+// /  Implements fmt.Formatter, so an IPAddress can be used directly
+// /  with the fmt verbs:
+// /
+// /    %s   canonical CIDR                 "2001:db8::8:800:200c:417a/64"
+// /    %+s  exploded CIDR                  "2001:0db8:0000:0000:0008:0800:200c:417a/64"
+// /    %#s  mapped dotted CIDR             "::ffff:10.0.0.1/32"
+// /    %v   bare address                   "2001:db8::8:800:200c:417a"
+// /    %+v  exploded bare address
+// /    %#v  mapped dotted bare address     "::ffff:10.0.0.1"
+// /    %q   quoted canonical CIDR
+// /    %x   hex digits of the address      "0a000001"
+// /    %X   upper case hex digits
+// /    %#x  hex digits with 0x prefix      "0x0a000001"
+// /    %b   binary digits of the address
+// /
+// /  Width and the - flag pad the result like for strings:
+// /
+// /    fmt.Sprintf("[%-16v]", Parse("10.0.0.1/8").Unwrap())
+// /      ///  "[10.0.0.1        ]"
+// /
+func (self IPAddress) Format(f fmt.State, verb rune) {
+	if self.Ip_bits == nil {
+		fmt.Fprintf(f, "%%!%c(ipaddress.IPAddress=<nil>)", verb)
+		return
+	}
+	var ret string
+	switch verb {
+	case 's', 'q':
+		switch {
+		case f.Flag('+'):
+			ret = self.To_string_uncompressed()
+		case f.Flag('#'):
+			ret = self.To_string_mapped()
+		default:
+			ret = self.To_string()
+		}
+		if verb == 'q' {
+			ret = fmt.Sprintf("%q", ret)
+		}
+	case 'v':
+		switch {
+		case f.Flag('+'):
+			ret = self.To_s_uncompressed()
+		case f.Flag('#'):
+			ret = self.To_s_mapped()
+		default:
+			ret = self.To_s()
+		}
+	case 'x', 'X':
+		ret = self.Host_address.Text(16)
+		ret = strings.Repeat("0", int(self.Ip_bits.Bits/4)-len(ret)) + ret
+		if verb == 'X' {
+			ret = strings.ToUpper(ret)
+		}
+		if f.Flag('#') {
+			ret = "0x" + ret
+		}
+	case 'b':
+		ret = self.Bits()
+	default:
+		fmt.Fprintf(f, "%%!%c(ipaddress.IPAddress=%s)", verb, self.To_string())
+		return
+	}
+	width, ok := f.Width()
+	if !ok || width <= len(ret) {
+		f.Write([]byte(ret))
+		return
+	}
+	pad := strings.Repeat(" ", width-len(ret))
+	if f.Flag('-') {
+		f.Write([]byte(ret + pad))
+	} else {
+		f.Write([]byte(pad + ret))
+	}
+}
@@ -0,0 +1,111 @@
+package ipaddress
+
+import "strconv"
+import "strings"
+
+// /  Options for To_s and To_string. The zero value is the
+// /  canonical text representation of RFC 5952:
+// /
+// /  * Uppercase: hex digits in upper case, "2001:DB8::1"
+// /  * Leading_zeros: every IPv6 group with 4 digits,
+// /    "2001:0db8::0001"
+// /  * No_compress: never replace zero groups with ::,
+// /    "2001:db8:0:0:0:0:0:1"
+// /  * Ipv4_tail: write the last 32 bits of any IPv6 address
+// /    as dotted decimal, "::ffff:10.0.0.1" or "2001:db8::0.0.0.1"
+// /  * Omit_host_prefix: To_string leaves out /32 or /128
+// /
+// /    ip = IPAddress("2001:db8::1/128")
+// /
+// /    ip.To_string(FormatOptions{Uppercase: true, Omit_host_prefix: true})
+// /      ///  "2001:DB8::1"
+// /
+// /  The options only change IPv6 groups, IPv4 is always written
+// /  as plain dotted decimal.
+// /
+type FormatOptions struct {
+	Uppercase        bool
+	Leading_zeros    bool
+	No_compress      bool
+	Ipv4_tail        bool
+	Omit_host_prefix bool
+}
+
+func format_options(opts []FormatOptions) FormatOptions {
+	if len(opts) == 0 {
+		return FormatOptions{}
+	}
+	return opts[0]
+}
+
+// returns start and length of the zero run which is replaced by
+// :: after RFC 5952 4.2: the longest run of at least two groups,
+// the first one if there is a tie
+func ipv6_zero_run(parts []uint16) (int, int) {
+	best_pos, best_cnt := -1, 1
+	for i := 0; i < len(parts); {
+		if parts[i] != 0 {
+			i++
+			continue
+		}
+		j := i
+		for j < len(parts) && parts[j] == 0 {
+			j++
+		}
+		if j-i > best_cnt {
+			best_pos, best_cnt = i, j-i
+		}
+		i = j
+	}
+	if best_pos < 0 {
+		return -1, 0
+	}
+	return best_pos, best_cnt
+}
+
+func ipv6_format_group(part uint16, opts FormatOptions) string {
+	ret := strconv.FormatUint(uint64(part), 16)
+	if opts.Leading_zeros {
+		ret = strings.Repeat("0", 4-len(ret)) + ret
+	}
+	if opts.Uppercase {
+		ret = strings.ToUpper(ret)
+	}
+	return ret
+}
+
+func ipv6_format_parts(parts []uint16, opts FormatOptions) string {
+	run_pos, run_cnt := -1, 0
+	if !opts.No_compress {
+		run_pos, run_cnt = ipv6_zero_run(parts)
+	}
+	var ret strings.Builder
+	for i := 0; i < len(parts); i++ {
+		if i == run_pos {
+			ret.WriteString("::")
+			i += run_cnt - 1
+			continue
+		}
+		if i > 0 && i != run_pos+run_cnt {
+			ret.WriteString(":")
+		}
+		ret.WriteString(ipv6_format_group(parts[i], opts))
+	}
+	return ret.String()
+}
+
+func (self *IPAddress) format_s(opts FormatOptions) string {
+	if self.Is_ipv4() {
+		return self.Ip_bits.As_compressed_string(self.Host_address)
+	}
+	parts := self.Ip_bits.Parts(self.Host_address)
+	if !opts.Ipv4_tail {
+		return ipv6_format_parts(parts, opts) + self.zone_suffix()
+	}
+	head := ipv6_format_parts(parts[:6], opts)
+	if !strings.HasSuffix(head, ":") {
+		head += ":"
+	}
+	tail := IpBitsV4().As_compressed_string(U128FromUint64(self.Host_address.Lo & 0xffffffff))
+	return head + tail + self.zone_suffix()
+}
@@ -0,0 +1,58 @@
+package ipaddress
+
+import "testing"
+
+func TestFormatOptions(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestFormatOptions", func(t *MyTesting) {
+		t.Run("test_rfc5952", func(t *MyTesting) {
+			for in, out := range map[string]string{
+				// 4.1 leading zeros
+				"2001:0db8::0001": "2001:db8::1",
+				// 4.2.1 shorten as much as possible
+				"2001:db8:0:0:0:0:2:1": "2001:db8::2:1",
+				// 4.2.2 no :: for a single zero group
+				"2001:db8:0:1:1:1:1:1": "2001:db8:0:1:1:1:1:1",
+				"2001:db8:1:1:1:1:1:0": "2001:db8:1:1:1:1:1:0",
+				// 4.2.3 longest run, the first on a tie
+				"2001:0:0:1:0:0:0:1":   "2001:0:0:1::1",
+				"2001:db8:0:0:1:0:0:1": "2001:db8::1:0:0:1",
+				// 4.3 lower case
+				"2001:DB8::AB":    "2001:db8::ab",
+				"0:0:0:0:0:0:0:0": "::",
+				"1:0:0:0:0:0:0:0": "1::",
+				"0:0:0:0:0:0:0:1": "::1",
+			} {
+				t.assert_string(out, Parse(in).Unwrap().To_s())
+			}
+		})
+		t.Run("test_options", func(t *MyTesting) {
+			ip := Parse("2001:db8:0:0:a:0:0:1/128").Unwrap()
+			t.assert_string("2001:DB8::A:0:0:1", ip.To_s(FormatOptions{Uppercase: true}))
+			t.assert_string("2001:0db8::000a:0000:0000:0001", ip.To_s(FormatOptions{Leading_zeros: true}))
+			t.assert_string("2001:db8:0:0:a:0:0:1", ip.To_s(FormatOptions{No_compress: true}))
+			t.assert_string("2001:0DB8:0000:0000:000A:0000:0000:0001",
+				ip.To_s(FormatOptions{Uppercase: true, Leading_zeros: true, No_compress: true}))
+			t.assert_string("2001:db8::a:0:0.0.0.1", ip.To_s(FormatOptions{Ipv4_tail: true}))
+			t.assert_string("2001:db8::a:0:0:1", ip.To_string(FormatOptions{Omit_host_prefix: true}))
+			t.assert_string("2001:db8::a:0:0:1/128", ip.To_string(FormatOptions{}))
+			t.assert_string("2001:db8::/64", Parse("2001:db8::/64").Unwrap().To_string(FormatOptions{Omit_host_prefix: true}))
+		})
+		t.Run("test_ipv4_tail", func(t *MyTesting) {
+			tail := FormatOptions{Ipv4_tail: true}
+			t.assert_string("::ffff:10.0.0.1", Parse("::ffff:10.0.0.1").Unwrap().To_s(tail))
+			t.assert_string("::0.0.0.1", Parse("::1").Unwrap().To_s(tail))
+			t.assert_string("::0.0.0.0", Parse("::").Unwrap().To_s(tail))
+			t.assert_string("0:0:0:0:0:ffff:10.0.0.1",
+				Parse("::ffff:10.0.0.1").Unwrap().To_s(FormatOptions{Ipv4_tail: true, No_compress: true}))
+			t.assert_string("fe80::1:0.0.0.1%eth0", Parse("fe80::1:0:1%eth0").Unwrap().To_s(tail))
+		})
+		t.Run("test_ipv4", func(t *MyTesting) {
+			ip := Parse("10.0.0.1").Unwrap()
+			opts := FormatOptions{Uppercase: true, Leading_zeros: true, No_compress: true, Ipv4_tail: true}
+			t.assert_string("10.0.0.1", ip.To_s(opts))
+			t.assert_string("10.0.0.1/32", ip.To_string(opts))
+			t.assert_string("10.0.0.1", ip.To_string(FormatOptions{Omit_host_prefix: true}))
+		})
+	})
+}
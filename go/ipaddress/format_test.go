@@ -0,0 +1,51 @@
+package ipaddress
+
+import "fmt"
+import "testing"
+
+func TestFormat(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestFormat", func(t *MyTesting) {
+		ip4 := Parse("10.0.0.1/8").Unwrap()
+		ip6 := Parse("2001:db8::8:800:200c:417a/64").Unwrap()
+		mapped := Parse("::ffff:10.0.0.1").Unwrap()
+		t.Run("test_string", func(t *MyTesting) {
+			t.assert_string("10.0.0.1/8", fmt.Sprintf("%s", ip4))
+			t.assert_string("2001:db8::8:800:200c:417a/64", fmt.Sprintf("%s", ip6))
+			t.assert_string("10.0.0.1/8", ip4.String())
+			t.assert_string("10.0.0.1/8", fmt.Sprintf("%s", *ip4))
+		})
+		t.Run("test_exploded", func(t *MyTesting) {
+			t.assert_string("2001:0db8:0000:0000:0008:0800:200c:417a/64", fmt.Sprintf("%+s", ip6))
+			t.assert_string("2001:0db8:0000:0000:0008:0800:200c:417a", fmt.Sprintf("%+v", ip6))
+		})
+		t.Run("test_mapped", func(t *MyTesting) {
+			t.assert_string("::ffff:10.0.0.1/32", fmt.Sprintf("%#s", mapped))
+			t.assert_string("::ffff:10.0.0.1", fmt.Sprintf("%#v", mapped))
+			t.assert_string("::ffff:a00:1", fmt.Sprintf("%v", mapped))
+			t.assert_string("10.0.0.1/8", fmt.Sprintf("%#s", ip4))
+		})
+		t.Run("test_bare", func(t *MyTesting) {
+			t.assert_string("10.0.0.1", fmt.Sprintf("%v", ip4))
+			t.assert_string("2001:db8::8:800:200c:417a", fmt.Sprintf("%v", ip6))
+			t.assert_string(`"10.0.0.1/8"`, fmt.Sprintf("%q", ip4))
+		})
+		t.Run("test_hex", func(t *MyTesting) {
+			t.assert_string("0a000001", fmt.Sprintf("%x", ip4))
+			t.assert_string("0x0a000001", fmt.Sprintf("%#x", ip4))
+			t.assert_string("20010DB80000000000080800200C417A", fmt.Sprintf("%X", ip6))
+		})
+		t.Run("test_binary", func(t *MyTesting) {
+			t.assert_string("00001010000000000000000000000001", fmt.Sprintf("%b", ip4))
+			t.assert_int(128, len(fmt.Sprintf("%b", ip6)))
+		})
+		t.Run("test_width", func(t *MyTesting) {
+			t.assert_string("[10.0.0.1        ]", fmt.Sprintf("[%-16v]", ip4))
+			t.assert_string("[      10.0.0.1/8]", fmt.Sprintf("[%16s]", ip4))
+		})
+		t.Run("test_bad_verb", func(t *MyTesting) {
+			t.assert_string("%!d(ipaddress.IPAddress=10.0.0.1/8)", fmt.Sprintf("%d", ip4))
+			t.assert_string("%!s(ipaddress.IPAddress=<nil>)", fmt.Sprintf("%s", IPAddress{}))
+		})
+	})
+}
@@ -1,3 +1,3 @@
 module github.com/mabels/ipaddress/go/ipaddress
 
-go 1.17
+go 1.23
@@ -1,27 +1,48 @@
 package ipaddress
 
-import "math/big"
 import "regexp"
-import "fmt"
 import "strconv"
 import "strings"
+import "unicode"
 
 var re_MAPPED = regexp.MustCompile(":.+\\.")
 var re_IPV4 = regexp.MustCompile("\\.")
 var re_IPV6 = regexp.MustCompile(":")
 
-func Parse(str string) ResultIPAddress {
-	if re_MAPPED.MatchString(str) {
-		return Ipv6MappedNew(str)
+func Parse(str string, opts ...ParseOptions) ResultIPAddress {
+	return result_ipaddress(ParseE(str, opts...))
+}
+
+// / Like Parse but returns the idiomatic (value, error) pair,
+// / the error is always a *ParseError
+// /
+// /   ip, err := ParseE("172.16.10.1/24")
+// /
+func ParseE(str string, opts ...ParseOptions) (*IPAddress, error) {
+	// the zone may contain dots and colons, like "eth0.100"
+	unzoned, has_zone := strip_zone(str)
+	if re_MAPPED.MatchString(unzoned) {
+		addr, _ := Split_at_slash(unzoned)
+		num, err := split_to_num(addr)
+		if top96 := num.Rsh(32); err == nil && !top96.Is_zero() && top96 != U128FromUint64(0xffff) {
+			// mixed notation like 64:ff9b::192.0.2.33/96
+			return Ipv6NewE(str)
+		}
+		return Ipv6MappedNewE(str, opts...)
 	} else {
-		if re_IPV4.MatchString(str) {
-			return Ipv4New(str)
-		} else if re_IPV6.MatchString(str) {
-			return Ipv6New(str)
+		if re_IPV4.MatchString(unzoned) {
+			if has_zone {
+				return nil, new_parse_error(ErrBadZone, str, strings.IndexByte(str, '%'), "IPv4 has no zone")
+			}
+			return Ipv4NewE(str, opts...)
+		} else if re_IPV6.MatchString(unzoned) {
+			return Ipv6NewE(str)
+		} else if parse_options(opts).Ipv4 == Ipv4InetAton && !has_zone {
+			// a single number like "0x7f000001"
+			return Ipv4NewE(str, opts...)
 		}
 	}
-	tmp := fmt.Sprintf("Unknown IP Address %s", str)
-	return &Error{&tmp}
+	return nil, new_parse_error(ErrInvalidAddress, str, 0, "unknown IP address")
 }
 
 // / Checks if the given string is a valid IP address,
@@ -35,12 +56,12 @@ func Parse(str string) ResultIPAddress {
 // /  IPAddress::valid? "10.0.0.256"
 // /    //=> false
 // /
-func Is_valid(addr string) bool {
-	return Is_valid_ipv4(addr) || Is_valid_ipv6(addr)
+func Is_valid(addr string, opts ...ParseOptions) bool {
+	return Is_valid_ipv4(addr, opts...) || Is_valid_ipv6(addr)
 }
 
-func Is_valid_netmask(addr string) bool {
-	ret, _ := Parse_netmask_to_prefix(addr)
+func Is_valid_netmask(addr string, opts ...ParseOptions) bool {
+	ret, _ := Parse_netmask_to_prefix(addr, opts...)
 	return ret != nil
 }
 
@@ -54,77 +75,76 @@ func Is_valid_netmask(addr string) bool {
 // /   IPAddress::valid_ipv4? "172.16.10.1"
 // /     //=> true
 // /
-func parse_ipv4_part(i string, addr string) (*uint32, *string) {
+func parse_ipv4_part(i string, addr string, ofs int) (uint32, *ParseError) {
 	part, err := strconv.ParseUint(i, 10, 32)
 	if err != nil {
-		tmp := fmt.Sprintf("IP must contain numbers %s", addr)
 		// fmt.Printf("parse_ipv4_part-1 %s:%s:%s:%s\n", i, part, err, addr, tmp)
-		return nil, &tmp
+		return 0, new_parse_error(ErrBadOctet, addr, ofs, "IP must contain numbers %q", i)
 	}
-	part_num := uint32(part)
-	if part_num >= 256 {
-		tmp := fmt.Sprintf("IP items has to lower than 256. %s", addr)
+	if part >= 256 {
 		// fmt.Printf("parse_ipv4_part-2\n")
-		return nil, &tmp
+		return 0, new_parse_error(ErrBadOctet, addr, ofs, "IP items has to lower than 256 %q", i)
 	}
 	// fmt.Printf("parse_ipv4_part-3: %d\n", part_num)
-	return &part_num, nil
+	return uint32(part), nil
 }
 
-func remove_string(stack []string, idx int) []string {
-	var p []string = nil
-	if idx < len(stack) {
-		p = make([]string, len(stack)-1)
-	} else {
-		p = make([]string, len(stack))
-	}
-	pidx := 0
-	for i, v := range stack {
-		if i != idx {
-			p[pidx] = v
-			pidx++
-		}
+// returns the string without surrounding white space and
+// the offset of its first character in str
+func trim_ofs(str string) (string, int) {
+	left := strings.TrimLeftFunc(str, unicode.IsSpace)
+	return strings.TrimRightFunc(left, unicode.IsSpace), len(str) - len(left)
+}
+
+// splits str at sep and returns the parts with their offsets
+func split_ofs(str string, sep string) ([]string, []int) {
+	parts := strings.Split(str, sep)
+	ofs := make([]int, len(parts))
+	pos := 0
+	for i, part := range parts {
+		ofs[i] = pos
+		pos += len(part) + len(sep)
 	}
-	return p
+	return parts, ofs
 }
 
-func split_to_u32(addr string) (*uint32, *string) {
+func split_to_u32(addr string) (uint32, *ParseError) {
 	ip := uint32(0)
 	shift := uint(24)
-	split_addr := strings.Split(strings.TrimSpace(addr), ".")
+	trimmed, lead := trim_ofs(addr)
+	split_addr, split_ofs := split_ofs(trimmed, ".")
 	split_addr_len := len(split_addr)
 	// fmt.Printf("u32-1\n")
 	if split_addr_len > 4 {
-		tmp := fmt.Sprintf("IP has not the right format:%s", addr)
 		// fmt.Printf("u32-1\n")
-		return nil, &tmp
+		return 0, new_parse_error(ErrInvalidAddress, addr, lead+split_ofs[4], "IP has not the right format")
 	}
 	if split_addr_len < 4 {
-		part, err := parse_ipv4_part(split_addr[split_addr_len-1], addr)
+		part, err := parse_ipv4_part(split_addr[split_addr_len-1], addr, lead+split_ofs[split_addr_len-1])
 		if err != nil {
 			// fmt.Printf("u32-4\n")
-			return nil, err
+			return 0, err
 		}
 		// fmt.Printf("split_to_u32:%s:%d:%s\n", addr, split_addr_len, split_addr)
-		ip = *part
-		split_addr = remove_string(split_addr, split_addr_len-1)
+		ip = part
+		split_addr = split_addr[:split_addr_len-1]
 	}
-	for _, i := range split_addr {
-		part, err := parse_ipv4_part(i, addr)
+	for idx, i := range split_addr {
+		part, err := parse_ipv4_part(i, addr, lead+split_ofs[idx])
 		if err != nil {
 			// fmt.Printf("u32-3 %s\n", err)
-			return nil, err
+			return 0, err
 		}
 		// fmt.Printf("{}-{}", part_num, shift);
-		ip = ip | (*part << shift)
+		ip = ip | (part << shift)
 		shift -= 8
 	}
 	// fmt.Printf("u32-2, %x\n", ip)
-	return &ip, nil
+	return ip, nil
 }
 
-func Is_valid_ipv4(addr string) bool {
-	_, err := split_to_u32(addr)
+func Is_valid_ipv4(addr string, opts ...ParseOptions) bool {
+	_, err := split_to_u32_mode(addr, parse_options(opts).Ipv4)
 	return err == nil
 }
 
@@ -138,66 +158,74 @@ func Is_valid_ipv4(addr string) bool {
 // /   IPAddress::valid_ipv6? "2002::DEAD::BEEF"
 // /     // => false
 // /
-func split_on_colon(addr string) (*big.Int, *string, uint) {
-	parts := strings.Split(strings.TrimSpace(addr), ":")
-	ip := big.NewInt(0)
-	parts_len := uint(len(parts))
-	// fmt.Printf("soc:%s:%d\n", addr, parts_len);
-	if parts_len == 1 && parts[0] == "" {
+func split_on_colon(addr string, ofs int, input string) (U128, *ParseError, uint) {
+	ip := U128{}
+	trimmed, lead := trim_ofs(addr)
+	if trimmed == "" {
 		return ip, nil, 0
 	}
+	parts, parts_ofs := split_ofs(trimmed, ":")
+	parts_len := uint(len(parts))
+	// fmt.Printf("soc:%s:%d\n", addr, parts_len);
 	shift := uint((parts_len - 1) * 16)
-	for _, i := range parts {
+	for idx, i := range parts {
 		part, err := strconv.ParseUint(i, 16, 32)
 		if err != nil {
-			tmp := fmt.Sprintf("IP must contain hex numbers %s->%s", addr, i)
-			return nil, &tmp, 0
+			return ip, new_parse_error(ErrBadOctet, input, ofs+lead+parts_ofs[idx], "IP must contain hex numbers %q", i), 0
 		}
-		part_num := int64(part)
-		if part_num >= 65536 {
-			tmp := fmt.Sprintf("IP items has to lower than 65536. %s", addr)
-			return nil, &tmp, 0
+		if part >= 65536 {
+			return ip, new_parse_error(ErrBadOctet, input, ofs+lead+parts_ofs[idx], "IP items has to lower than 65536 %q", i), 0
 		}
-		bi_part_num := big.NewInt(part_num)
-		bi_part_num = bi_part_num.Lsh(bi_part_num, shift)
-		ip = ip.Add(ip, bi_part_num)
+		ip = ip.Or(U128FromUint64(part).Lsh(shift))
 		shift -= 16
 	}
 	return ip, nil, parts_len
 }
 
-func split_to_num(addr string) (*big.Int, *string) {
+func split_to_num(addr string) (U128, *ParseError) {
 	//let mut ip = 0;
-	pre_post := strings.Split(strings.TrimSpace(addr), "::")
+	trimmed, lead := trim_ofs(addr)
+	if dot := strings.IndexByte(trimmed, '.'); dot >= 0 {
+		// mixed notation, the last 32 bits are a strict dotted quad
+		colon := strings.LastIndexByte(trimmed, ':')
+		if colon < 0 || colon > dot || strings.Count(trimmed[colon+1:], ".") != 3 {
+			return U128{}, new_parse_error(ErrInvalidAddress, addr, lead+dot, "embedded IPv4 must be the last 32 bits")
+		}
+		ipv4, err := split_to_u32_strict(trimmed[colon+1:])
+		if err != nil {
+			return U128{}, err.rebase(addr, lead+colon+1)
+		}
+		trimmed = trimmed[:colon+1] + strconv.FormatUint(uint64(ipv4>>16), 16) + ":" +
+			strconv.FormatUint(uint64(ipv4&0xffff), 16)
+	}
+	pre_post, pre_post_ofs := split_ofs(trimmed, "::")
 	if len(pre_post) > 2 {
-		tmp := fmt.Sprintf("IPv6 only allow one :: %s", addr)
 		// fmt.Printf("stn-1:%s:%s\n", addr, tmp)
-		return nil, &tmp
+		return U128{}, new_parse_error(ErrTooManyDoubleColon, addr, lead+pre_post_ofs[2]-2, "IPv6 only allow one ::")
 	}
 	if len(pre_post) == 2 {
 		//fmt.Printf("{}=::={}", pre_post[0], pre_post[1]);
-		pre, err, pre_parts := split_on_colon(pre_post[0])
+		pre, err, pre_parts := split_on_colon(pre_post[0], lead, addr)
 		if err != nil {
 			// fmt.Printf("stn-2:%s:[%s]:%s\n", addr, pre_post[0], *err)
-			return nil, err
+			return U128{}, err
 		}
-		post, err, _ := split_on_colon(pre_post[1])
+		post, err, _ := split_on_colon(pre_post[1], lead+pre_post_ofs[1], addr)
 		if err != nil {
 			// fmt.Printf("stn-3:%s:%s\n", addr, err)
-			return nil, err
+			return U128{}, err
 		}
 		// fmt.Printf("pre:{} post:{}", pre_parts, post_parts);
 		// fmt.Printf("stn-4:%s\n", addr)
-		return big.NewInt(0).Add(pre.Lsh(pre, 128-(pre_parts*16)), post), nil
+		return pre.Lsh(128 - (pre_parts * 16)).Add(post), nil
 	}
 	//fmt.Printf("split_to_num:no double:{}", addr);
-	ret, err, parts := split_on_colon(addr)
+	ret, err, parts := split_on_colon(trimmed, lead, addr)
 	if err != nil {
-		return nil, err
+		return U128{}, err
 	}
 	if parts != 128/16 {
-		tmp := fmt.Sprintf("incomplete IPv6")
-		return nil, &tmp
+		return U128{}, new_parse_error(ErrInvalidAddress, addr, lead+len(trimmed), "incomplete IPv6")
 	}
 	return ret, nil
 }
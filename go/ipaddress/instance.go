@@ -1,20 +1,21 @@
 package ipaddress
 
-import "strings"
 import "math/big"
 import "math"
 import "sort"
 import "fmt"
 import "bytes"
+import "slices"
+import "strings"
 
 type Error struct {
-	err *string
+	err error
 }
 
 func (self *Error) IsOk() bool         { return false }
 func (self *Error) IsErr() bool        { return true }
 func (self *Error) Unwrap() *IPAddress { return nil }
-func (self *Error) UnwrapErr() *string { return self.err }
+func (self *Error) UnwrapErr() *string { return error_string(self.err) }
 
 // func Error(err *string) *ErrorIsh {
 //     return &ErrorIsh{err}
@@ -30,13 +31,13 @@ func (self *Ok) Unwrap() *IPAddress { return self.ipaddress }
 func (self *Ok) UnwrapErr() *string { return nil }
 
 type Errors struct {
-	err *string
+	err error
 }
 
 func (self *Errors) IsOk() bool            { return false }
 func (self *Errors) IsErr() bool           { return true }
 func (self *Errors) Unwrap() *[]*IPAddress { return nil }
-func (self *Errors) UnwrapErr() *string    { return self.err }
+func (self *Errors) UnwrapErr() *string    { return error_string(self.err) }
 
 type Oks struct {
 	ipaddresses *[]*IPAddress
@@ -47,26 +48,32 @@ func (self *Oks) IsErr() bool           { return false }
 func (self *Oks) Unwrap() *[]*IPAddress { return self.ipaddresses }
 func (self *Oks) UnwrapErr() *string    { return nil }
 
-func (self *IPAddress) Clone() *IPAddress {
-	mapped := self.Mapped
-	if self.Mapped != nil {
-		tmp := *self.Mapped
-		tmp2 := tmp.Clone()
-		mapped = tmp2
+func error_string(err error) *string {
+	tmp := err.Error()
+	return &tmp
+}
+
+func result_ipaddress(ip *IPAddress, err error) ResultIPAddress {
+	if err != nil {
+		return &Error{err}
 	}
-	ret := new(IPAddress)
-	ret.Ip_bits = self.Ip_bits
-	ret.Host_address = self.Host_address
-	ret.Prefix = *self.Prefix.Clone()
-	ret.Mapped = mapped
-	ret.Vt_is_private = self.Vt_is_private
-	ret.Vt_is_loopback = self.Vt_is_loopback
-	ret.Vt_to_ipv6 = self.Vt_to_ipv6
-	return ret
+	return &Ok{ip}
+}
+
+func result_ipaddresses(ips *[]*IPAddress, err error) ResultIPAddresses {
+	if err != nil {
+		return &Errors{err}
+	}
+	return &Oks{ips}
+}
+
+func (self *IPAddress) Clone() *IPAddress {
+	ret := *self
+	return &ret
 }
 
 func (self *IPAddress) String() string {
-	return fmt.Sprintf("IPAddress: %s", self.To_string())
+	return self.To_string()
 }
 
 func (self *IPAddress) Eq(oth *IPAddress) bool {
@@ -89,20 +96,20 @@ func (self *IPAddress) Cmp(oth *IPAddress) int {
 		return -1
 	}
 	//let adr_diff = self.host_address - oth.host_address;
-	if self.Host_address.Cmp(&oth.Host_address) < 0 {
-		return -1
-	} else if self.Host_address.Cmp(&oth.Host_address) > 0 {
-		return 1
+	if cmp := self.Host_address.Cmp(oth.Host_address); cmp != 0 {
+		return cmp
 	}
-	return self.Prefix.Cmp(&oth.Prefix)
+	if cmp := self.Prefix.Cmp(&oth.Prefix); cmp != 0 {
+		return cmp
+	}
+	return strings.Compare(self.Zone, oth.Zone)
 }
 
 func (self *IPAddress) Equal(other IPAddress) bool {
 	return self.Ip_bits.Version == other.Ip_bits.Version &&
 		self.Prefix.Cmp(&other.Prefix) == 0 &&
-		self.Host_address.Cmp(&other.Host_address) == 0 &&
-		((self.Mapped == nil && self.Mapped == other.Mapped) ||
-			self.Mapped.Equal(*other.Mapped))
+		self.Host_address == other.Host_address &&
+		self.Zone == other.Zone
 }
 
 /// Parse the argument string to create a new
@@ -124,29 +131,24 @@ func (self *IPAddress) Equal(other IPAddress) bool {
 ///
 
 func Split_at_slash(str string) (string, *string) {
-	trimmed := strings.TrimSpace(str)
-	slash := strings.Split(trimmed, "/")
-	addr := ""
-	if len(slash) >= 1 {
-		addr = strings.TrimSpace(slash[0])
-	}
+	addr, _, netmask, _ := split_at_slash_ofs(str)
+	return addr, netmask
+}
+
+// like Split_at_slash but also returns the offsets
+// of the address and the netmask within str
+func split_at_slash_ofs(str string) (string, int, *string, int) {
+	slash, slash_ofs := split_ofs(str, "/")
+	addr, addr_ofs := trim_ofs(slash[0])
 	if len(slash) >= 2 {
-		tslash := strings.TrimSpace(slash[1])
-		return addr, &tslash
+		tslash, tslash_ofs := trim_ofs(slash[1])
+		return addr, addr_ofs, &tslash, slash_ofs[1] + tslash_ofs
 	}
-	return addr, nil
+	return addr, addr_ofs, nil, 0
 }
 
-func (self *IPAddress) From(addr *big.Int, prefix *Prefix) *IPAddress {
-	padr := new(IPAddress)
-	padr.Ip_bits = self.Ip_bits
-	padr.Host_address = *big.NewInt(0).Set(addr)
-	padr.Prefix = *prefix.Clone()
-	padr.Mapped = self.Mapped
-	padr.Vt_is_private = self.Vt_is_private
-	padr.Vt_is_loopback = self.Vt_is_loopback
-	padr.Vt_to_ipv6 = self.Vt_to_ipv6
-	return padr
+func (self *IPAddress) From(addr U128, prefix *Prefix) *IPAddress {
+	return &IPAddress{self.Ip_bits, addr, *prefix, self.Zone}
 }
 
 // / True if the object is an IPv4 address
@@ -172,7 +174,19 @@ func (self *IPAddress) Is_ipv6() bool {
 }
 
 func (self *IPAddress) Parts() []uint16 {
-	return self.Ip_bits.Parts(&self.Host_address)
+	return self.Ip_bits.Parts(self.Host_address)
+}
+
+// /  Returns the host address as big.Int for callers
+// /  which need arbitrary-precision math
+// /
+// /    ip = IPAddress("10.0.0.1")
+// /
+// /    ip.host_address_big
+// /      ///  167772161
+// /
+func (self *IPAddress) Host_address_big() *big.Int {
+	return self.Host_address.Big()
 }
 
 func (self *IPAddress) Parts_hex_str() []string {
@@ -219,43 +233,19 @@ func (self *IPAddress) Dns_reverse() string {
 }
 
 func (self *IPAddress) dns_parts() []uint8 {
-	num := big.NewInt(0).Set(&self.Host_address)
-	mask := big.NewInt(0).Lsh(big.NewInt(1), uint(self.Ip_bits.Dns_bits))
+	num := self.Host_address
+	mask := uint64(1)<<self.Ip_bits.Dns_bits - 1
 	cnt := int(self.Ip_bits.Bits / self.Ip_bits.Dns_bits)
 	ret := make([]uint8, cnt)
 	for i := 0; i < cnt; i++ {
-		rem := big.NewInt(0).Set(num)
-		part := uint8(rem.Rem(rem, mask).Uint64())
-		num = num.Rsh(num, uint(self.Ip_bits.Dns_bits))
-		ret[i] = part
+		ret[i] = uint8(num.Lo & mask)
+		num = num.Rsh(uint(self.Ip_bits.Dns_bits))
 	}
 	return ret
 }
 
 func (self *IPAddress) Dns_networks() []*IPAddress {
-	// +self.ip_bits.dns_bits-1
-	next_bit_mask := self.Ip_bits.Bits -
-		(((self.Prefix.Host_prefix()) / self.Ip_bits.Dns_bits) * self.Ip_bits.Dns_bits)
-	if next_bit_mask <= 0 {
-		return []*IPAddress{self.Network()}
-	}
-	//  fmt.Printf("dns_networks:{}:{}", self.to_string(), next_bit_mask);
-	// dns_bits
-	step_bit_net := big.NewInt(0).Lsh(big.NewInt(1), uint(self.Ip_bits.Bits-next_bit_mask))
-	if step_bit_net.Cmp(big.NewInt(0)) == 0 {
-		return []*IPAddress{self.Network()}
-	}
-	ret := []*IPAddress{}
-	step := big.NewInt(0).Set(&self.Network().Host_address)
-	resPrefix := self.Prefix.From(next_bit_mask)
-	baddr := self.Broadcast().Host_address
-	for baddr.Cmp(step) >= 0 {
-		addr := self.From(step, resPrefix.Unwrap())
-		ret = append(ret, addr)
-		// fmt.Printf("dns_networks:%s\n", addr.To_string())
-		step = step.Add(step, step_bit_net)
-	}
-	return ret
+	return slices.Collect(self.Dns_networks_seq())
 }
 
 func (self *IPAddress) Ip_same_kind(oth *IPAddress) bool {
@@ -268,7 +258,7 @@ func (self *IPAddress) Ip_same_kind(oth *IPAddress) bool {
 ///
 
 func (self *IPAddress) Is_unspecified() bool {
-	return self.Host_address.Cmp(big.NewInt(0)) == 0
+	return self.Host_address.Is_zero()
 }
 
 ///  Returns true if the address is a loopback address
@@ -277,7 +267,7 @@ func (self *IPAddress) Is_unspecified() bool {
 ///
 
 func (self *IPAddress) Is_loopback() bool {
-	return (self.Vt_is_loopback)(self)
+	return self.Classify().Type == TypeLoopback
 }
 
 ///  Returns true if the address is a mapped address
@@ -286,11 +276,27 @@ func (self *IPAddress) Is_loopback() bool {
 ///
 
 func (self *IPAddress) Is_mapped() bool {
-	num := big.NewInt(0).Set(&self.Host_address)
-	mask := big.NewInt(0).Lsh(big.NewInt(1), 16)
-	// fmt.Printf("Is_mapped:%s\n", self.Mapped)
-	return self.Mapped != nil &&
-		num.Rsh(num, 32).Cmp(mask.Sub(mask, big.NewInt(1))) == 0
+	return self.Is_ipv6() &&
+		self.Prefix.Host_prefix() <= IpBitsV4().Bits &&
+		self.Host_address.Rsh(32) == U128FromUint64(0xffff) &&
+		self.Host_address.Lo&0xffffffff != 0
+}
+
+///  Returns the IPv4 portion of a mapped IPv6 address
+///  or nil if the address is not mapped
+///
+///    ip6 = IPAddress("::ffff:ac10:a01/120")
+///
+///    ip6.mapped.to_string
+///      ///  "172.16.10.1/24"
+///
+
+func (self *IPAddress) Mapped() *IPAddress {
+	if !self.Is_mapped() {
+		return nil
+	}
+	return From_u32(uint32(self.Host_address.Lo),
+		IpBitsV4().Bits-self.Prefix.Host_prefix()).Unwrap()
 }
 
 ///  Returns the prefix portion of the IPv4 object
@@ -334,20 +340,20 @@ func (self *IPAddress) Is_mapped() bool {
 // /      ///  172.16.100.4/22
 // /
 func (self *IPAddress) Change_prefix(num uint8) ResultIPAddress {
-	prefix := self.Prefix.From(num)
-	if prefix.IsErr() {
-		return &Error{prefix.UnwrapErr()}
+	prefix, err := self.Prefix.FromE(num)
+	if err != nil {
+		return &Error{err}
 	}
-	from := self.From(&self.Host_address, prefix.Unwrap())
+	from := self.From(self.Host_address, prefix)
 	return &Ok{from}
 }
 
 func (self *IPAddress) Change_netmask(my_str string) ResultIPAddress {
-	nm, err := Parse_netmask_to_prefix(my_str)
+	nm, err := parse_netmask(my_str, self.Ip_bits.Bits, ParseOptions{})
 	if err != nil {
 		return &Error{err}
 	}
-	return self.Change_prefix(*nm)
+	return self.Change_prefix(nm)
 }
 
 ///  Returns a string with the IP address in canonical
@@ -359,16 +365,30 @@ func (self *IPAddress) Change_netmask(my_str string) ResultIPAddress {
 ///      ///  "172.16.100.4/22"
 ///
 
-func (self *IPAddress) To_string() string {
+func (self *IPAddress) To_string(opts ...FormatOptions) string {
 	var ret bytes.Buffer
-	ret.WriteString(self.To_s())
+	ret.WriteString(self.To_s(opts...))
+	if format_options(opts).Omit_host_prefix && self.Prefix.Num == self.Ip_bits.Bits {
+		return ret.String()
+	}
 	ret.WriteString("/")
 	ret.WriteString(self.Prefix.To_s())
 	return ret.String()
 }
 
-func (self *IPAddress) To_s() string {
-	return self.Ip_bits.As_compressed_string(&self.Host_address)
+// /  Returns the address without prefix in the canonical form
+// /  of RFC 5952, or as selected by FormatOptions
+// /
+// /    ip = IPAddress("2001:db8:0:0:1:0:0:1/64")
+// /
+// /    ip.To_s()
+// /      ///  "2001:db8::1:0:0:1"
+// /
+func (self *IPAddress) To_s(opts ...FormatOptions) string {
+	if len(opts) == 0 {
+		return self.Ip_bits.As_compressed_string(self.Host_address) + self.zone_suffix()
+	}
+	return self.format_s(opts[0])
 }
 
 func (self *IPAddress) To_string_uncompressed() string {
@@ -380,19 +400,19 @@ func (self *IPAddress) To_string_uncompressed() string {
 }
 
 func (self *IPAddress) To_s_uncompressed() string {
-	return self.Ip_bits.As_uncompressed_string(&self.Host_address)
+	return self.Ip_bits.As_uncompressed_string(self.Host_address) + self.zone_suffix()
 }
 
 func (self *IPAddress) To_s_mapped() string {
 	if self.Is_mapped() {
-		return fmt.Sprintf("::ffff:%s", self.Mapped.To_s())
+		return fmt.Sprintf("::ffff:%s%s", self.Mapped().To_s(), self.zone_suffix())
 	}
 	return self.To_s()
 }
 
 func (self *IPAddress) To_string_mapped() string {
 	if self.Is_mapped() {
-		mapped := self.Mapped
+		mapped := self.Mapped()
 		return fmt.Sprintf("%s/%d",
 			self.To_s_mapped(),
 			mapped.Prefix.Num)
@@ -424,7 +444,7 @@ func (self *IPAddress) To_hex() string {
 }
 
 func (self *IPAddress) Netmask() *IPAddress {
-	return self.From(&self.Prefix.Netmask, &self.Prefix)
+	return self.From(self.Prefix.Netmask, &self.Prefix)
 }
 
 ///  Returns the broadcast address for the given IP.
@@ -436,10 +456,7 @@ func (self *IPAddress) Netmask() *IPAddress {
 ///
 
 func (self *IPAddress) Broadcast() *IPAddress {
-	size := self.Size()
-	h_a := self.Network().Host_address
-	return self.From(big.NewInt(0).Add(&h_a,
-		big.NewInt(0).Sub(&size, big.NewInt(1))), &self.Prefix)
+	return self.From(self.Host_address.Or(self.Prefix.Host_mask()), &self.Prefix)
 	// IPv4::parse_u32(self.broadcast_u32, self.Prefix)
 }
 
@@ -457,9 +474,8 @@ func (self *IPAddress) Broadcast() *IPAddress {
 ///
 
 func (self *IPAddress) Is_network() bool {
-	net := self.Network().Host_address
 	return self.Prefix.Num != self.Ip_bits.Bits &&
-		self.Host_address.Cmp(&net) == 0
+		self.Host_address == self.Host_address.And(self.Prefix.Netmask)
 }
 
 ///  Returns a new IPv4 object with the network number
@@ -472,25 +488,19 @@ func (self *IPAddress) Is_network() bool {
 ///
 
 func (self *IPAddress) Network() *IPAddress {
-	to_n := To_network(&self.Host_address, self.Prefix.Host_prefix())
 	// fmt.Printf("Network:0:%s\n", self.Host_address)
-	// fmt.Printf("Network:1:%s\n", self.Host_address.String())
-	// fmt.Printf("Network:2:%s\n", to_n.String())
-	return self.From(&to_n, &self.Prefix)
+	return self.From(self.Host_address.And(self.Prefix.Netmask), &self.Prefix)
 }
 
-func To_network(adr *big.Int, host_prefix uint8) big.Int {
-	num := big.NewInt(0).Set(adr)
-	num.Rsh(num, uint(host_prefix)).Lsh(num, uint(host_prefix))
-	return *num
+func To_network(adr U128, host_prefix uint8) U128 {
+	return adr.Rsh(uint(host_prefix)).Lsh(uint(host_prefix))
 }
 
 func (self *IPAddress) Sub(other *IPAddress) big.Int {
-	ret := big.NewInt(0)
-	if self.Host_address.Cmp(&other.Host_address) > 0 {
-		return *ret.Sub(&self.Host_address, &other.Host_address)
+	if self.Host_address.Cmp(other.Host_address) > 0 {
+		return *self.Host_address.Sub(other.Host_address).Big()
 	}
-	return *ret.Sub(&other.Host_address, &self.Host_address)
+	return *other.Host_address.Sub(self.Host_address).Big()
 }
 
 func (self *IPAddress) Add(other *IPAddress) *[]*IPAddress {
@@ -516,12 +526,18 @@ func (self *IPAddress) Add(other *IPAddress) *[]*IPAddress {
 // /    ip.first.to_s
 // /      ///  "192.168.100.1"
 // /
+// /  The all-ones network has no next address, First stays at
+// /  the network address then.
+// /
 func (self *IPAddress) First() *IPAddress {
 	ha := self.Network().Host_address
 	// always the first USABLE host: network + 1, for IPv6 too (the
 	// zero host is the subnet-router anycast, not an ordinary
 	// unicast — 2026-08-19)
-	return self.From(big.NewInt(0).Add(&ha, big.NewInt(1)), &self.Prefix)
+	if ha != self.Ip_bits.All_ones {
+		ha = ha.Add(U128FromUint64(1))
+	}
+	return self.From(ha, &self.Prefix)
 }
 
 ///  Like its sibling method IPv4/// first, this method
@@ -544,10 +560,29 @@ func (self *IPAddress) First() *IPAddress {
 ///    ip.last.to_s
 ///      ///  "192.168.100.254"
 ///
+///  The IPv4 network 0.0.0.0/32 has no previous address, Last
+///  stays at the broadcast address then.
+///
 
 func (self *IPAddress) Last() *IPAddress {
 	ha := self.Broadcast().Host_address
-	return self.From(big.NewInt(0).Sub(&ha, &self.Ip_bits.Host_ofs), &self.Prefix)
+	if ha.Cmp(self.Ip_bits.Host_ofs) >= 0 {
+		ha = ha.Sub(self.Ip_bits.Host_ofs)
+	}
+	return self.From(ha, &self.Prefix)
+}
+
+// the first and last host address, false if the network has
+// no usable hosts
+func (self *IPAddress) host_range() (U128, U128, bool) {
+	network := self.Network().Host_address
+	broadcast := self.Broadcast().Host_address
+	if network == self.Ip_bits.All_ones || broadcast.Cmp(self.Ip_bits.Host_ofs) < 0 {
+		return network, broadcast, false
+	}
+	first := network.Add(U128FromUint64(1))
+	last := broadcast.Sub(self.Ip_bits.Host_ofs)
+	return first, last, first.Cmp(last) <= 0
 }
 
 ///  Iterates over all the hosts IP addresses for the given
@@ -567,12 +602,8 @@ func (self *IPAddress) Last() *IPAddress {
 ///
 
 func (self *IPAddress) Each_host(fn func(*IPAddress)) {
-	i := self.First().Host_address
-	last := self.Last().Host_address
-	for i.Cmp(&last) <= 0 {
-		ip := self.From(&i, &self.Prefix)
+	for ip := range self.Each_host_seq() {
 		fn(ip)
-		i.Add(&i, big.NewInt(1))
 	}
 }
 
@@ -598,12 +629,8 @@ func (self *IPAddress) Each_host(fn func(*IPAddress)) {
 ///
 
 func (self *IPAddress) Each(fn func(*IPAddress)) {
-	i := self.Network().Host_address
-	broad := self.Broadcast().Host_address
-	for broad.Cmp(&i) >= 0 {
-		ip := self.From(&i, &self.Prefix)
+	for ip := range self.Each_seq() {
 		fn(ip)
-		i.Add(&i, big.NewInt(1))
 	}
 }
 
@@ -675,11 +702,9 @@ func (self *IPAddress) Is_same_kind(oth *IPAddress) bool {
 ///
 
 func (self *IPAddress) Includes(oth *IPAddress) bool {
-	to_n := To_network(&oth.Host_address, self.Prefix.Host_prefix())
-	h_a := self.Network().Host_address
 	ret := self.Is_same_kind(oth) &&
 		self.Prefix.Num <= oth.Prefix.Num &&
-		h_a.Cmp(&to_n) == 0
+		self.Host_address.And(self.Prefix.Netmask) == oth.Host_address.And(self.Prefix.Netmask)
 	// fmt.Printf("includes:{}=={}=>{}", self.to_string(), oth.to_string(), ret);
 	return ret
 }
@@ -706,7 +731,9 @@ func (self *IPAddress) Includes_all(oths *[]*IPAddress) bool {
 }
 
 ///  Checks if an IPv4 address objects belongs
-///  to a private network RFC1918
+///  to a private network RFC1918, or an IPv6 address
+///  to the unique local addresses fc00::/7 (RFC 4193),
+///  the same range Is_unique_local checks
 ///
 ///  Example:
 ///
@@ -715,8 +742,29 @@ func (self *IPAddress) Includes_all(oths *[]*IPAddress) bool {
 ///      ///  true
 ///
 
+var private_networks_val []*IPAddress
+
+// the networks Is_private checks, 169.254.0.0/16 is kept
+// for compatibility although it is not RFC 1918
+func private_networks() []*IPAddress {
+	if private_networks_val == nil {
+		private_networks_val = []*IPAddress{
+			Parse("10.0.0.0/8").Unwrap(),
+			Parse("169.254.0.0/16").Unwrap(),
+			Parse("172.16.0.0/12").Unwrap(),
+			Parse("192.168.0.0/16").Unwrap(),
+			Parse("fc00::/7").Unwrap()}
+	}
+	return private_networks_val
+}
+
 func (self *IPAddress) Is_private() bool {
-	return (self.Vt_is_private)(self)
+	for _, ip := range private_networks() {
+		if ip.Includes(self) {
+			return true
+		}
+	}
+	return false
 }
 
 ///  Splits a network into different subnets
@@ -752,21 +800,24 @@ func (self *IPAddress) Is_private() bool {
 ///
 
 func (self *IPAddress) Split(subnets uint) ResultIPAddresses {
+	return result_ipaddresses(self.SplitE(subnets))
+}
+
+func (self *IPAddress) SplitE(subnets uint) (*[]*IPAddress, error) {
 	if subnets == 0 || (1<<self.Prefix.Host_prefix()) <= subnets {
-		out := fmt.Sprintf("Value %d out of range", subnets)
-		return &Errors{&out}
+		return nil, new_parse_error(ErrPrefixRange, self.To_string(), 0,
+			"Value %d out of range", subnets)
 	}
 	prefix, _ := self.Newprefix(subnets)
-	networks := self.Subnet(prefix.Num)
-	if networks.IsErr() {
-		return networks
+	networks, err := self.SubnetE(prefix.Num)
+	if err != nil {
+		return nil, err
 	}
-	for uint(len(*networks.Unwrap())) != subnets {
+	for uint(len(*networks)) != subnets {
 		// fmt.Printf("Split:%d:%d\n", len(*networks.Unwrap()), subnets)
-		tmp := Sum_first_found(networks.Unwrap())
-		networks = &Oks{tmp}
+		networks = Sum_first_found(networks)
 	}
-	return networks
+	return networks, nil
 }
 
 ///  Returns a new IPv4 object from the supernetting
@@ -794,10 +845,14 @@ func (self *IPAddress) Split(subnets uint) ResultIPAddresses {
 ///
 
 func (self *IPAddress) Supernet(new_prefix uint8) ResultIPAddress {
+	return result_ipaddress(self.SupernetE(new_prefix))
+}
+
+func (self *IPAddress) SupernetE(new_prefix uint8) (*IPAddress, error) {
 	if new_prefix >= self.Prefix.Num {
-		ret := fmt.Sprintf("New prefix must be smaller than existing prefix: %d >= %d",
+		return nil, new_parse_error(ErrPrefixRange, self.To_string(), 0,
+			"New prefix must be smaller than existing prefix: %d >= %d",
 			new_prefix, self.Prefix.Num)
-		return &Error{&ret}
 	}
 	// let mut new_ip = self.host_address.clone();
 	// for _ in new_prefix..self.Prefix.num {
@@ -805,9 +860,9 @@ func (self *IPAddress) Supernet(new_prefix uint8) ResultIPAddress {
 	// }
 	tmp := self.Host_address
 	tmp3 := self.Prefix.From(new_prefix).Unwrap()
-	tmp2 := self.From(&tmp, tmp3)
+	tmp2 := self.From(tmp, tmp3)
 	tmp4 := tmp2.Network()
-	return &Ok{tmp4}
+	return tmp4, nil
 }
 
 ///  This method implements the subnetting function
@@ -833,25 +888,21 @@ func (self *IPAddress) Supernet(new_prefix uint8) ResultIPAddress {
 ///
 
 func (self *IPAddress) Subnet(subprefix uint8) ResultIPAddresses {
-	if subprefix < self.Prefix.Num || self.Ip_bits.Bits < subprefix {
-		tmp := fmt.Sprintf("New prefix must be between prefix%d %d and %d",
-			self.Prefix.Num,
-			subprefix,
-			self.Ip_bits.Bits)
-		return &Errors{&tmp}
+	return result_ipaddresses(self.SubnetE(subprefix))
+}
+
+func (self *IPAddress) SubnetE(subprefix uint8) (*[]*IPAddress, error) {
+	seq, err := self.Subnet_seq(subprefix)
+	if err != nil {
+		return nil, err
 	}
-	net := self.Network()
-	net.Prefix = *net.Prefix.From(subprefix).Unwrap()
-	cnt := (1 << (subprefix - self.Prefix.Num))
-	ret := make([]*IPAddress, cnt)
-	for i := 0; i < cnt; i++ {
-		ret[i] = net
-		pre := net.Prefix
-		net = net.From(&net.Host_address, &pre)
-		size := net.Size()
-		net.Host_address.Add(&net.Host_address, &size)
+	if subprefix-self.Prefix.Num > 31 {
+		// a slice of this size can not be allocated, use Subnet_seq
+		return nil, new_parse_error(ErrPrefixRange, self.To_string(), 0,
+			"too many subnets 2^%d, use Subnet_seq", subprefix-self.Prefix.Num)
 	}
-	return &Oks{&ret}
+	ret := slices.Collect(seq)
+	return &ret, nil
 }
 
 ///  Return the ip address in a format compatible
@@ -866,7 +917,10 @@ func (self *IPAddress) Subnet(subprefix uint8) ResultIPAddresses {
 ///
 
 func (self *IPAddress) To_ipv6() *IPAddress {
-	return (self.Vt_to_ipv6)(self)
+	if self.Is_ipv4() {
+		return ipv4_to_ipv6(self)
+	}
+	return ipv6_to_ipv6(self)
 }
 
 //  private methods
@@ -1193,15 +1247,12 @@ func To_string_vec(vec *[]*IPAddress) []string {
 func To_ipaddress_vec(vec []string) ResultIPAddresses {
 	ret := make([]*IPAddress, len(vec))
 	for idx, ipstr := range vec {
-		ipa := Parse(ipstr)
-		if ipa.IsErr() {
-			// fmt.Printf("To_ipaddress_vec:Err:%s\n", ipa.UnwrapErr())
-			return &Errors{ipa.UnwrapErr()}
+		ipa, err := ParseE(ipstr)
+		if err != nil {
+			// fmt.Printf("To_ipaddress_vec:Err:%s\n", err)
+			return &Errors{err}
 		}
-		// fmt.Printf("To_ipaddress_vec:%s:%s:%s\n", ipstr,
-		// 	ipa.Unwrap().To_string(),
-		// 	ipa.Unwrap())
-		ret[idx] = ipa.Unwrap()
+		ret[idx] = ipa
 	}
 	return &Oks{&ret}
 }
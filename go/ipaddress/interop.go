@@ -0,0 +1,145 @@
+package ipaddress
+
+import "net"
+import "net/netip"
+
+// /  Conversions from and to the address types of the standard
+// /  library. All conversions are lossless, an IPv4-mapped IPv6
+// /  address stays an IPv6 address whose Mapped() is the IPv4
+// /  part:
+// /
+// /    ip, _ := FromNetipAddr(netip.MustParseAddr("::ffff:10.0.0.1"))
+// /    ip.Mapped().To_s()
+// /      ///  "10.0.0.1"
+// /
+// /  Things which can not be represented, like a zone which is not
+// /  valid for Is_valid_zone, return an error which unwraps to
+// /  ErrConversion.
+// /
+
+func ipaddress_from_bytes(b []byte, prefix uint8) (*IPAddress, error) {
+	switch len(b) {
+	case 4:
+		ret, err := Prefix32NewE(prefix)
+		if err != nil {
+			return nil, err
+		}
+		return &IPAddress{IpBitsV4(), u128_from_bytes(b), *ret, ""}, nil
+	case 16:
+		ret, err := Prefix128NewE(prefix)
+		if err != nil {
+			return nil, err
+		}
+		return enhance_if_mapped(&IPAddress{IpBitsV6(), u128_from_bytes(b), *ret, ""})
+	}
+	return nil, new_parse_error(ErrConversion, net.IP(b).String(), 0, "address must have 4 or 16 bytes, got: %d", len(b))
+}
+
+// /  Creates a host address (/32 or /128) from a netip.Addr
+// /
+// /    ip, _ := FromNetipAddr(netip.MustParseAddr("172.16.10.1"))
+// /    ip.To_string()
+// /      ///  "172.16.10.1/32"
+// /
+func FromNetipAddr(addr netip.Addr) (*IPAddress, error) {
+	if !addr.IsValid() {
+		return nil, new_parse_error(ErrInvalidAddress, addr.String(), 0, "invalid netip.Addr")
+	}
+	ret, err := ipaddress_from_bytes(addr.AsSlice(), uint8(addr.BitLen()))
+	if err != nil || addr.Zone() == "" {
+		return ret, err
+	}
+	if !Is_valid_zone(addr.Zone()) {
+		return nil, new_parse_error(ErrConversion, addr.String(), 0, "non canonical zone %q", addr.Zone())
+	}
+	ret.Zone = addr.Zone()
+	return ret, nil
+}
+
+// /  Creates an address with prefix from a netip.Prefix, the
+// /  host bits of the prefix address are kept. A netip.Prefix
+// /  never has a zone.
+// /
+// /    ip, _ := FromNetipPrefix(netip.MustParsePrefix("2001:db8::8:800:200c:417a/64"))
+// /    ip.To_string()
+// /      ///  "2001:db8::8:800:200c:417a/64"
+// /
+func FromNetipPrefix(prefix netip.Prefix) (*IPAddress, error) {
+	if !prefix.IsValid() {
+		return nil, new_parse_error(ErrInvalidAddress, prefix.String(), 0, "invalid netip.Prefix")
+	}
+	return ipaddress_from_bytes(prefix.Addr().AsSlice(), uint8(prefix.Bits()))
+}
+
+// /  Creates a host address from a net.IP. A 4 byte net.IP is an
+// /  IPv4 address, a 16 byte net.IP is an IPv6 address, which is
+// /  mapped if it is in the ::ffff:0:0/96 range. This is the same
+// /  rule netip.AddrFromSlice uses.
+// /
+func FromNetIP(ip net.IP) (*IPAddress, error) {
+	return ipaddress_from_bytes(ip, uint8(len(ip)*8))
+}
+
+// /  Creates an address with prefix from a net.IPNet, the mask
+// /  has to be canonical (contiguous ones followed by zeros)
+// /
+// /    _, ipnet, _ := net.ParseCIDR("10.0.0.0/8")
+// /    ip, _ := FromNetIPNet(ipnet)
+// /    ip.To_string()
+// /      ///  "10.0.0.0/8"
+// /
+func FromNetIPNet(ipnet *net.IPNet) (*IPAddress, error) {
+	if ipnet == nil {
+		return nil, new_parse_error(ErrInvalidAddress, "<nil>", 0, "nil net.IPNet")
+	}
+	ones, bits := ipnet.Mask.Size()
+	if bits == 0 {
+		return nil, new_parse_error(ErrBadNetmask, ipnet.Mask.String(), 0, "non canonical netmask")
+	}
+	ip := ipnet.IP
+	if bits == 32 && len(ip) == 16 {
+		ip = ip.To4()
+	}
+	if ip == nil || len(ip)*8 != bits {
+		return nil, new_parse_error(ErrConversion, ipnet.String(), 0, "address and netmask length differ")
+	}
+	return ipaddress_from_bytes(ip, uint8(ones))
+}
+
+// /  Returns the address without prefix as netip.Addr
+// /
+func (self *IPAddress) To_netip_addr() netip.Addr {
+	b := self.Host_address.bytes16()
+	if self.Is_ipv4() {
+		return netip.AddrFrom4([4]byte{b[12], b[13], b[14], b[15]})
+	}
+	return netip.AddrFrom16(b).WithZone(self.Zone)
+}
+
+// /  Returns the address with its prefix as netip.Prefix, the host
+// /  bits are not masked and the zone is dropped
+// /
+func (self *IPAddress) To_netip_prefix() netip.Prefix {
+	return netip.PrefixFrom(self.To_netip_addr().WithZone(""), int(self.Prefix.Num))
+}
+
+// /  Returns the address as net.IP, 4 bytes long for IPv4 and
+// /  16 bytes long for IPv6
+// /
+func (self *IPAddress) To_net_ip() net.IP {
+	b := self.Host_address.bytes16()
+	if self.Is_ipv4() {
+		return net.IP(b[12:])
+	}
+	return net.IP(b[:])
+}
+
+// /  Returns the address with its prefix as net.IPNet, the host
+// /  bits are not masked
+// /
+func (self *IPAddress) To_net_ipnet() *net.IPNet {
+	return &net.IPNet{
+		IP:   self.To_net_ip(),
+		Mask: net.CIDRMask(int(self.Prefix.Num), int(self.Ip_bits.Bits)),
+	}
+}
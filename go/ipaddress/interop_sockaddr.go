@@ -0,0 +1,59 @@
+//go:build !plan9
+
+package ipaddress
+
+import "net"
+import "strconv"
+import "syscall"
+
+// /  Creates a host address and returns the port of a
+// /  syscall.SockaddrInet4 or syscall.SockaddrInet6
+// /
+// /    ip, port, _ := FromSockaddr(&syscall.SockaddrInet4{Port: 80, Addr: [4]byte{10, 0, 0, 1}})
+// /    ip.To_string()
+// /      ///  "10.0.0.1/32"
+// /    port
+// /      ///  80
+// /
+func FromSockaddr(sa syscall.Sockaddr) (*IPAddress, int, error) {
+	switch sa := sa.(type) {
+	case *syscall.SockaddrInet4:
+		ip, err := ipaddress_from_bytes(sa.Addr[:], 32)
+		return ip, sa.Port, err
+	case *syscall.SockaddrInet6:
+		ip, err := ipaddress_from_bytes(sa.Addr[:], 128)
+		if err == nil && sa.ZoneId != 0 {
+			ip.Zone = strconv.FormatUint(uint64(sa.ZoneId), 10)
+		}
+		return ip, sa.Port, err
+	}
+	return nil, 0, new_parse_error(ErrConversion, "", 0, "unsupported sockaddr %T", sa)
+}
+
+// /  Returns a syscall.SockaddrInet4 or syscall.SockaddrInet6
+// /  for the address and the given port. The zone of an IPv6
+// /  address is either a numeric zone id or the name of an
+// /  interface, unknown interfaces yield zone id 0.
+// /
+func (self *IPAddress) To_sockaddr(port int) syscall.Sockaddr {
+	b := self.Host_address.bytes16()
+	if self.Is_ipv4() {
+		ret := &syscall.SockaddrInet4{Port: port}
+		copy(ret.Addr[:], b[12:])
+		return ret
+	}
+	return &syscall.SockaddrInet6{Port: port, ZoneId: zone_id(self.Zone), Addr: b}
+}
+
+func zone_id(zone string) uint32 {
+	if zone == "" {
+		return 0
+	}
+	if id, err := strconv.ParseUint(zone, 10, 32); err == nil {
+		return uint32(id)
+	}
+	if ifi, err := net.InterfaceByName(zone); err == nil {
+		return uint32(ifi.Index)
+	}
+	return 0
+}
@@ -0,0 +1,32 @@
+//go:build !plan9
+
+package ipaddress
+
+import "errors"
+import "syscall"
+import "testing"
+
+func TestInteropSockaddr(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestInteropSockaddr", func(t *MyTesting) {
+		t.Run("test_sockaddr", func(t *MyTesting) {
+			ip, port, err := FromSockaddr(&syscall.SockaddrInet4{Port: 80, Addr: [4]byte{10, 0, 0, 1}})
+			t.assert(err == nil)
+			t.assert_int(80, port)
+			t.assert_string("10.0.0.1/32", ip.To_string())
+			sa := ip.To_sockaddr(80).(*syscall.SockaddrInet4)
+			t.assert(sa.Addr == [4]byte{10, 0, 0, 1})
+			ip6 := Parse("2001:db8::1").Unwrap()
+			ip, port, err = FromSockaddr(ip6.To_sockaddr(443))
+			t.assert(err == nil)
+			t.assert_int(443, port)
+			t.assert(*ip == *ip6)
+			ip, _, _ = FromSockaddr(&syscall.SockaddrInet6{ZoneId: 2, Addr: [16]byte{0: 0xfe, 1: 0x80, 15: 1}})
+			t.assert_string("fe80::1%2/128", ip.To_string())
+			sa6 := ip.To_sockaddr(22).(*syscall.SockaddrInet6)
+			t.assert(sa6.ZoneId == 2)
+			_, _, err = FromSockaddr(&syscall.SockaddrUnix{Name: "/tmp/x"})
+			t.assert(errors.Is(err, ErrConversion))
+		})
+	})
+}
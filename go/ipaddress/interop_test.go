@@ -0,0 +1,89 @@
+package ipaddress
+
+import "errors"
+import "net"
+import "net/netip"
+import "testing"
+
+func TestInterop(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestInterop", func(t *MyTesting) {
+		t.Run("test_netip_addr", func(t *MyTesting) {
+			for _, str := range []string{"172.16.10.1", "2001:db8::8:800:200c:417a", "::ffff:10.0.0.1", "::"} {
+				addr := netip.MustParseAddr(str)
+				ip, err := FromNetipAddr(addr)
+				t.assert(err == nil)
+				t.assert(ip.To_netip_addr() == addr)
+			}
+			ip, _ := FromNetipAddr(netip.MustParseAddr("172.16.10.1"))
+			t.assert_string("172.16.10.1/32", ip.To_string())
+			ip, _ = FromNetipAddr(netip.MustParseAddr("::ffff:10.0.0.1"))
+			t.assert(ip.Is_ipv6())
+			t.assert(ip.Is_mapped())
+			t.assert_string("10.0.0.1/32", ip.Mapped().To_string())
+			t.assert(Parse("::ffff:10.0.0.1").Unwrap().To_netip_addr().Is4In6())
+		})
+		t.Run("test_netip_addr_errors", func(t *MyTesting) {
+			_, err := FromNetipAddr(netip.Addr{})
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			ip, err := FromNetipAddr(netip.MustParseAddr("fe80::1%eth0"))
+			t.assert(err == nil)
+			t.assert_string("eth0", ip.Zone)
+			t.assert(ip.To_netip_addr() == netip.MustParseAddr("fe80::1%eth0"))
+			t.assert(ip.To_netip_prefix() == netip.MustParsePrefix("fe80::1/128"))
+			_, err = FromNetipAddr(netip.MustParseAddr("fe80::1%eth 0"))
+			t.assert(errors.Is(err, ErrConversion))
+		})
+		t.Run("test_netip_prefix", func(t *MyTesting) {
+			for _, str := range []string{"10.0.0.0/8", "172.16.10.1/24", "2001:db8::8:800:200c:417a/64", "::ffff:10.0.0.1/112"} {
+				prefix := netip.MustParsePrefix(str)
+				ip, err := FromNetipPrefix(prefix)
+				t.assert(err == nil)
+				t.assert(ip.To_netip_prefix() == prefix)
+			}
+			ip, _ := FromNetipPrefix(netip.MustParsePrefix("172.16.10.1/24"))
+			t.assert_string("172.16.10.1/24", ip.To_string())
+			_, err := FromNetipPrefix(netip.Prefix{})
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = FromNetipPrefix(netip.MustParsePrefix("::ffff:10.0.0.1/64"))
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+		t.Run("test_net_ip", func(t *MyTesting) {
+			ip, err := FromNetIP(net.IP{10, 0, 0, 1})
+			t.assert(err == nil)
+			t.assert_string("10.0.0.1/32", ip.To_string())
+			t.assert(ip.To_net_ip().Equal(net.IP{10, 0, 0, 1}))
+			t.assert_int(4, len(ip.To_net_ip()))
+			ip, _ = FromNetIP(net.ParseIP("10.0.0.1"))
+			t.assert(ip.Is_mapped())
+			ip, _ = FromNetIP(net.ParseIP("2001:db8::1"))
+			t.assert_string("2001:db8::1/128", ip.To_string())
+			t.assert_int(16, len(ip.To_net_ip()))
+			_, err = FromNetIP(net.IP{1, 2, 3})
+			t.assert(errors.Is(err, ErrConversion))
+		})
+		t.Run("test_net_ipnet", func(t *MyTesting) {
+			_, ipnet, _ := net.ParseCIDR("10.0.0.0/8")
+			ip, err := FromNetIPNet(ipnet)
+			t.assert(err == nil)
+			t.assert_string("10.0.0.0/8", ip.To_string())
+			t.assert_string("10.0.0.0/8", ip.To_net_ipnet().String())
+			ip, _ = FromNetIPNet(&net.IPNet{IP: net.ParseIP("172.16.10.1"), Mask: net.CIDRMask(24, 32)})
+			t.assert_string("172.16.10.1/24", ip.To_string())
+			_, ipnet, _ = net.ParseCIDR("2001:db8::/32")
+			ip, _ = FromNetIPNet(ipnet)
+			t.assert_string("2001:db8::/32", ip.To_string())
+			t.assert_string("2001:db8::/32", ip.To_net_ipnet().String())
+			_, err = FromNetIPNet(&net.IPNet{IP: net.IP{10, 0, 0, 0}, Mask: net.IPv4Mask(255, 0, 255, 0)})
+			t.assert(errors.Is(err, ErrBadNetmask))
+			_, err = FromNetIPNet(&net.IPNet{IP: net.ParseIP("2001:db8::"), Mask: net.CIDRMask(8, 32)})
+			t.assert(errors.Is(err, ErrConversion))
+		})
+		t.Run("test_stdlib_round_trip", func(t *MyTesting) {
+			a, _ := FromNetipPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+			b, _ := FromNetipPrefix(netip.MustParsePrefix("10.0.1.0/24"))
+			agg := Aggregate(&[]*IPAddress{a, b})
+			t.assert(netip.MustParsePrefix("10.0.0.0/23") == (*agg)[0].To_netip_prefix())
+		})
+	})
+}
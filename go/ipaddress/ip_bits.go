@@ -3,22 +3,22 @@ package ipaddress
 import (
 	"bytes"
 	"fmt"
-	"math/big"
 )
 
 type IpBits struct {
 	Version                   Family
-	Vt_as_compressed_string   func(ipb *IpBits, d *big.Int) string
-	Vt_as_uncompressed_string func(ipb *IpBits, d *big.Int) string
+	Vt_as_compressed_string   func(ipb *IpBits, d U128) string
+	Vt_as_uncompressed_string func(ipb *IpBits, d U128) string
 	Bits                      uint8
 	Part_bits                 uint8
 	Dns_bits                  uint8
 	Rev_domain                string
-	Part_mod                  big.Int
-	Host_ofs                  big.Int // ipv4=1, ipv6=0
+	Part_mod                  uint32
+	Host_ofs                  U128 // ipv4=1, ipv6=0
+	All_ones                  U128 // all Bits set
 }
 
-func ipv4_as_compressed(ip_bits *IpBits, host_address *big.Int) string {
+func ipv4_as_compressed(ip_bits *IpBits, host_address U128) string {
 	var ret bytes.Buffer
 	sep := ""
 	for _, part := range ip_bits.Parts(host_address) {
@@ -30,32 +30,16 @@ func ipv4_as_compressed(ip_bits *IpBits, host_address *big.Int) string {
 	return ret.String()
 }
 
-func ipv6_as_compressed(ip_bits *IpBits, host_address *big.Int) string {
+func ipv6_as_compressed(ip_bits *IpBits, host_address U128) string {
 	//println!("ipv6_as_compressed:{}", host_address);
-	var ret bytes.Buffer
-	the_colon := ":"
-	the_empty := ""
-	colon := &the_empty
-	done := false
-	for _, rle := range Code(ip_bits.Parts(host_address)) {
-		// println!(">>{:?}", rle);
-		for i := 0; i < rle.Cnt; i++ {
-			if done || !(rle.Part == 0 && rle.Max) {
-				ret.WriteString(fmt.Sprintf("%s%x", *colon, rle.Part))
-				colon = &the_colon
-			} else if rle.Part == 0 && rle.Max {
-				ret.WriteString("::")
-				colon = &the_empty
-				done = true
-				break
-			}
-		}
-	}
-	// fmt.Printf("ipv6_as_compressed:%s\n", ret.String())
-	return ret.String()
+	return ipv6_compress_parts(ip_bits.Parts(host_address))
+}
+
+func ipv6_compress_parts(parts []uint16) string {
+	return ipv6_format_parts(parts, FormatOptions{})
 }
 
-func ipv6_as_uncompressed(ip_bits *IpBits, host_address *big.Int) string {
+func ipv6_as_uncompressed(ip_bits *IpBits, host_address U128) string {
 	var ret bytes.Buffer
 	sep := ""
 	for _, part := range ip_bits.Parts(host_address) {
@@ -75,8 +59,9 @@ func v4const() *IpBits {
 	ret.Part_bits = 8
 	ret.Dns_bits = 8
 	ret.Rev_domain = "in-addr.arpa"
-	ret.Part_mod = *big.NewInt(1 << 8)
-	ret.Host_ofs = *big.NewInt(1)
+	ret.Part_mod = 1 << 8
+	ret.Host_ofs = U128FromUint64(1)
+	ret.All_ones = u128_ones(32)
 	return ret
 }
 
@@ -89,45 +74,33 @@ func v6const() *IpBits {
 	ret.Part_bits = 16
 	ret.Dns_bits = 4
 	ret.Rev_domain = "ip6.arpa"
-	ret.Part_mod = *big.NewInt(1 << 16)
-	ret.Host_ofs = *big.NewInt(0)
+	ret.Part_mod = 1 << 16
+	ret.Host_ofs = U128FromUint64(0)
+	ret.All_ones = u128_ones(128)
 	return ret
 }
 
 func (ipb IpBits) String() string {
-	return fmt.Sprintf("IpBits:version:{%d},bits:{%d},part_bits:{%d},dns_bits:{%d},rev_domain:{%s},part_mod:{%s},host_ofs:{%s}",
-		ipb.Version, ipb.Bits, ipb.Part_bits, ipb.Dns_bits, ipb.Rev_domain, ipb.Part_mod.String(), ipb.Host_ofs.String())
-}
-
-func reverse(numbers []uint16) []uint16 {
-	for i := 0; i < len(numbers)/2; i++ {
-		j := len(numbers) - i - 1
-		numbers[i], numbers[j] = numbers[j], numbers[i]
-	}
-	return numbers
+	return fmt.Sprintf("IpBits:version:{%d},bits:{%d},part_bits:{%d},dns_bits:{%d},rev_domain:{%s},part_mod:{%d},host_ofs:{%s}",
+		ipb.Version, ipb.Bits, ipb.Part_bits, ipb.Dns_bits, ipb.Rev_domain, ipb.Part_mod, ipb.Host_ofs.String())
 }
 
-func (self *IpBits) Parts(bu *big.Int) []uint16 {
-	vec := make([]uint16, 0)
-	my := big.NewInt(0).Set(bu)
-	// part_mod := BigUint::one() << self.part_bits;// - BigUint::one();
-	for i := uint8(0); i < (self.Bits / self.Part_bits); i++ {
-		//func (z *Int) Mod(x, y *Int) *Int
-		// func (x *Int) Int64() int64
-		// func (z *Int) Rem(x, y *Int) *Int
-		rem := big.NewInt(0).Set(my)
-		rem.Rem(rem, &self.Part_mod)
-		vec = append(vec, uint16(rem.Int64()))
-		my.Rsh(my, uint(self.Part_bits))
+func (self *IpBits) Parts(bu U128) []uint16 {
+	cnt := self.Bits / self.Part_bits
+	vec := make([]uint16, cnt)
+	my := bu
+	for i := uint8(0); i < cnt; i++ {
+		vec[cnt-1-i] = uint16(my.Lo % uint64(self.Part_mod))
+		my = my.Rsh(uint(self.Part_bits))
 	}
-	return reverse(vec)
+	return vec
 }
 
-func (self *IpBits) As_compressed_string(bu *big.Int) string {
+func (self *IpBits) As_compressed_string(bu U128) string {
 	return (self.Vt_as_compressed_string)(self, bu)
 }
 
-func (self *IpBits) As_uncompressed_string(bu *big.Int) string {
+func (self *IpBits) As_uncompressed_string(bu U128) string {
 	return (self.Vt_as_uncompressed_string)(self, bu)
 }
 
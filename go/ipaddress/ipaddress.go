@@ -1,15 +1,23 @@
 package ipaddress
 
-import "math/big"
-
+// /  IPAddress is a plain value: it holds no pointers to other
+// /  addresses and no function tables, so two addresses can be
+// /  compared with == and used as map keys.
+// /
+// /    seen := map[IPAddress]bool{}
+// /    seen[*Parse("10.0.0.1/24").Unwrap()] = true
+// /
+// /  None of the methods modify the receiver or their arguments,
+// /  they always return new objects.
+// /
+// /  Zone is the scope of an IPv6 address like "eth0" in
+// /  "fe80::1%eth0", it is empty for IPv4 and unscoped addresses.
+// /
 type IPAddress struct {
-	Ip_bits        *IpBits
-	Host_address   big.Int
-	Prefix         Prefix
-	Mapped         *IPAddress
-	Vt_is_private  func(*IPAddress) bool
-	Vt_is_loopback func(*IPAddress) bool
-	Vt_to_ipv6     func(*IPAddress) *IPAddress
+	Ip_bits      *IpBits
+	Host_address U128
+	Prefix       Prefix
+	Zone         string
 }
 
 type ResultIPAddress interface {
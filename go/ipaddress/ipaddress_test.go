@@ -52,6 +52,37 @@ func TestIpAddress(tx *testing.T) {
 			t.assert_bool(false, Is_valid("2002:516:2:200"))
 			t.assert_bool(false, Is_valid("2002.:1"))
 		})
+		t.Run("test_comparable_values", func(t *MyTesting) {
+			a := *Parse("10.0.0.1/24").Unwrap()
+			b := *Parse("10.0.0.1/24").Unwrap()
+			c := *Parse("10.0.0.1/25").Unwrap()
+			t.assert(a == b)
+			t.assert(a != c)
+			seen := map[IPAddress]int{}
+			for _, str := range []string{"10.0.0.1/24", "10.0.0.1/24", "::ffff:10.0.0.1",
+				"::ffff:a00:1", "2001:db8::1/64", "2001:db8:0::1/64"} {
+				seen[*Parse(str).Unwrap()]++
+			}
+			t.assert_int(3, len(seen))
+			t.assert_int(2, seen[a])
+			t.assert_int(2, seen[*Parse("::ffff:a00:1/128").Unwrap()])
+		})
+		t.Run("test_equal_mapped", func(t *MyTesting) {
+			mapped := Parse("::ffff:10.0.0.1").Unwrap()
+			plain := Parse("::ffff:0:0").Unwrap()
+			t.assert(mapped.Is_mapped())
+			t.assert(!plain.Is_mapped())
+			t.assert_bool(false, mapped.Equal(*plain))
+			t.assert_bool(false, plain.Equal(*mapped))
+			t.assert_bool(true, mapped.Equal(*mapped.Clone()))
+		})
+		t.Run("test_from_does_not_share", func(t *MyTesting) {
+			ip := Parse("::ffff:ac10:a01/120").Unwrap()
+			net := ip.Network()
+			t.assert_string("172.16.10.1/24", ip.Mapped().To_string())
+			t.assert_string("172.16.10.0/24", net.Mapped().To_string())
+			t.assert(ip.Mapped() != net.Mapped())
+		})
 		t.Run("test_module_method_valid_ipv4_netmark", func(t *MyTesting) {
 			t.assert_bool(true, Is_valid_netmask("255.255.255.0"))
 			t.assert_bool(false, Is_valid_netmask("10.0.0.1"))
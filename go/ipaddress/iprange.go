@@ -0,0 +1,271 @@
+package ipaddress
+
+import "iter"
+import "math/big"
+import "sort"
+import "strings"
+
+// /  IPRange is an arbitrary range of addresses between First and
+// /  Last inclusive, which need not be aligned to a prefix:
+// /
+// /    r, _ := ParseRange("10.0.0.5-10.0.0.77")
+// /
+// /    r.To_cidrs()
+// /      ///  ["10.0.0.5/32", "10.0.0.6/31", "10.0.0.8/29",
+// /      ///   "10.0.0.16/28", "10.0.0.32/27", "10.0.0.64/29",
+// /      ///   "10.0.0.72/30", "10.0.0.76/31"]
+// /
+// /  First and Last are host addresses (/32 or /128) of the same
+// /  family. Like IPAddress an IPRange is a plain value which can
+// /  be compared with ==.
+// /
+type IPRange struct {
+	First IPAddress
+	Last  IPAddress
+}
+
+// returns ip as host address without prefix
+func host_of(ip *IPAddress) IPAddress {
+	return IPAddress{ip.Ip_bits, ip.Host_address, *ip.Prefix.From(ip.Ip_bits.Bits).Unwrap(), ip.Zone}
+}
+
+// /  Creates the range between the addresses first and last, the
+// /  prefixes of both are ignored
+// /
+func IPRangeNew(first *IPAddress, last *IPAddress) (*IPRange, error) {
+	if !first.Is_same_kind(last) {
+		return nil, new_parse_error(ErrInvalidAddress, first.To_s()+"-"+last.To_s(), 0,
+			"range must not mix IPv4 and IPv6")
+	}
+	if first.Zone != last.Zone {
+		return nil, new_parse_error(ErrBadZone, first.To_s()+"-"+last.To_s(), 0,
+			"range must not mix zones")
+	}
+	if first.Host_address.Cmp(last.Host_address) > 0 {
+		return nil, new_parse_error(ErrInvalidAddress, first.To_s()+"-"+last.To_s(), 0,
+			"first address is greater than last address")
+	}
+	return &IPRange{host_of(first), host_of(last)}, nil
+}
+
+// /  Parses a range in the form "first-last" or a CIDR, which is
+// /  the range from its network to its broadcast address
+// /
+// /    r, _ := ParseRange("2001:db8::1 - 2001:db8::ff")
+// /    r, _ := ParseRange("10.0.0.0/24")
+// /
+func ParseRange(str string, opts ...ParseOptions) (*IPRange, error) {
+	dash := range_separator(str, opts...)
+	if dash < 0 {
+		ip, err := ParseE(str, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return ip.To_range(), nil
+	}
+	first, err := ParseE(str[:dash], opts...)
+	if err != nil {
+		return nil, err
+	}
+	last, err := ParseE(str[dash+1:], opts...)
+	if err != nil {
+		return nil, err.(*ParseError).rebase(str, dash+1)
+	}
+	if first.Prefix.Num != first.Ip_bits.Bits || last.Prefix.Num != last.Ip_bits.Bits {
+		return nil, new_parse_error(ErrPrefixRange, str, 0, "range bounds must not have a prefix")
+	}
+	ret, err := IPRangeNew(first, last)
+	if err != nil {
+		return nil, err.(*ParseError).rebase(str, 0)
+	}
+	return ret, nil
+}
+
+// the dash between first and last, zones may contain dashes
+// themselves, so within a zone the first dash which leaves a
+// parseable last address wins
+func range_separator(str string, opts ...ParseOptions) int {
+	dash := strings.IndexByte(str, '-')
+	zone := strings.IndexByte(str, '%')
+	if dash < 0 || zone < 0 || dash < zone {
+		return dash
+	}
+	for i := dash; i < len(str); i++ {
+		if str[i] != '-' {
+			continue
+		}
+		if _, err := ParseE(str[i+1:], opts...); err == nil {
+			return i
+		}
+	}
+	return dash
+}
+
+// /  Returns the range from the network to the broadcast
+// /  address of self
+// /
+// /    ip = IPAddress("10.0.0.0/24")
+// /
+// /    ip.To_range().To_s()
+// /      ///  "10.0.0.0-10.0.0.255"
+// /
+func (self *IPAddress) To_range() *IPRange {
+	return &IPRange{host_of(self.Network()), host_of(self.Broadcast())}
+}
+
+func (self *IPRange) To_s() string {
+	return self.First.To_s() + "-" + self.Last.To_s()
+}
+
+func (self *IPRange) String() string {
+	return self.To_s()
+}
+
+func (self *IPRange) Is_ipv4() bool {
+	return self.First.Is_ipv4()
+}
+
+func (self *IPRange) Is_ipv6() bool {
+	return self.First.Is_ipv6()
+}
+
+func (self *IPRange) Cmp(oth *IPRange) int {
+	if cmp := self.First.Cmp(&oth.First); cmp != 0 {
+		return cmp
+	}
+	return self.Last.Cmp(&oth.Last)
+}
+
+// /  Returns the number of addresses in the range
+// /
+// /    r, _ := ParseRange("10.0.0.5-10.0.0.77")
+// /
+// /    r.Size()
+// /      ///  73
+// /
+func (self *IPRange) Size() big.Int {
+	ret := self.Last.Host_address.Sub(self.First.Host_address).Big()
+	return *ret.Add(ret, big.NewInt(1))
+}
+
+// /  Checks if all addresses of ip (its network to its
+// /  broadcast) are in the range
+// /
+// /    r, _ := ParseRange("10.0.0.5-10.0.0.77")
+// /
+// /    r.Includes(IPAddress("10.0.0.8/29"))
+// /      ///  true
+// /    r.Includes(IPAddress("10.0.0.0/29"))
+// /      ///  false
+// /
+func (self *IPRange) Includes(ip *IPAddress) bool {
+	return self.First.Is_same_kind(ip) &&
+		self.First.Host_address.Cmp(ip.Network().Host_address) <= 0 &&
+		self.Last.Host_address.Cmp(ip.Broadcast().Host_address) >= 0
+}
+
+// /  Checks if oth is completely within the range
+// /
+func (self *IPRange) Includes_range(oth *IPRange) bool {
+	return self.First.Is_same_kind(&oth.First) &&
+		self.First.Host_address.Cmp(oth.First.Host_address) <= 0 &&
+		self.Last.Host_address.Cmp(oth.Last.Host_address) >= 0
+}
+
+// /  Checks if the ranges have at least one address in common
+// /
+func (self *IPRange) Overlaps(oth *IPRange) bool {
+	return self.First.Is_same_kind(&oth.First) &&
+		self.First.Host_address.Cmp(oth.Last.Host_address) <= 0 &&
+		oth.First.Host_address.Cmp(self.Last.Host_address) <= 0
+}
+
+// /  Yields every address of the range as host address
+// /
+func (self *IPRange) Each_seq() iter.Seq[*IPAddress] {
+	return self.First.seq_between(self.First.Host_address, self.Last.Host_address)
+}
+
+// /  Calls fn for every address of the range
+// /
+func (self *IPRange) Each(fn func(*IPAddress)) {
+	for ip := range self.Each_seq() {
+		fn(ip)
+	}
+}
+
+// /  Returns the minimal list of networks which cover
+// /  exactly the range
+// /
+func (self *IPRange) To_cidrs() *[]*IPAddress {
+	ret := []*IPAddress{}
+	bits := uint(self.First.Ip_bits.Bits)
+	cur := self.First.Host_address
+	last := self.Last.Host_address
+	for {
+		// the largest block which starts at cur and ends
+		// before last
+		host_bits := cur.trailing_zeros()
+		if host_bits > bits {
+			host_bits = bits
+		}
+		for host_bits > 0 && cur.Add(u128_ones(uint8(host_bits))).Cmp(last) > 0 {
+			host_bits--
+		}
+		prefix := self.First.Prefix.From(uint8(bits - host_bits)).Unwrap()
+		ret = append(ret, self.First.From(cur, prefix))
+		end := cur.Add(u128_ones(uint8(host_bits)))
+		if end.Cmp(last) >= 0 {
+			break
+		}
+		cur = end.Add(U128FromUint64(1))
+	}
+	return Aggregate(&ret)
+}
+
+// /  Merges overlapping and adjacent ranges, the result is
+// /  sorted with IPv4 first
+// /
+// /    Merge_ranges([]*IPRange{"10.0.0.0-10.0.0.9", "10.0.0.10-10.0.0.20"})
+// /      ///  ["10.0.0.0-10.0.0.20"]
+// /
+func Merge_ranges(ranges []*IPRange) []*IPRange {
+	sorted := make([]*IPRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	ret := []*IPRange{}
+	for _, r := range sorted {
+		if len(ret) > 0 {
+			top := ret[len(ret)-1]
+			next := top.Last.Host_address.Add(U128FromUint64(1))
+			adjacent := !next.Is_zero() && next == r.First.Host_address
+			if top.First.Is_same_kind(&r.First) && top.First.Zone == r.First.Zone &&
+				(top.Overlaps(r) || adjacent) {
+				if r.Last.Host_address.Cmp(top.Last.Host_address) > 0 {
+					top.Last = r.Last
+				}
+				continue
+			}
+		}
+		tmp := *r
+		ret = append(ret, &tmp)
+	}
+	return ret
+}
+
+// /  Converts a list of networks into the merged list of
+// /  ranges they cover
+// /
+// /    Cidrs_to_ranges(["10.0.0.0/25", "10.0.0.128/26", "10.0.1.0/24"])
+// /      ///  ["10.0.0.0-10.0.0.191", "10.0.1.0-10.0.1.255"]
+// /
+func Cidrs_to_ranges(networks *[]*IPAddress) []*IPRange {
+	aggregated := Aggregate(networks)
+	ranges := make([]*IPRange, len(*aggregated))
+	for i, net := range *aggregated {
+		ranges[i] = net.To_range()
+	}
+	return Merge_ranges(ranges)
+}
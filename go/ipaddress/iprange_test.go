@@ -0,0 +1,142 @@
+package ipaddress
+
+import "errors"
+import "math/big"
+import "slices"
+import "testing"
+
+func range_strings(ranges []*IPRange) []string {
+	ret := make([]string, len(ranges))
+	for i, r := range ranges {
+		ret[i] = r.To_s()
+	}
+	return ret
+}
+
+func must_range(str string) *IPRange {
+	ret, err := ParseRange(str)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+func TestIPRange(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestIPRange", func(t *MyTesting) {
+		t.Run("test_parse", func(t *MyTesting) {
+			r, err := ParseRange("10.0.0.5-10.0.0.77")
+			t.assert(err == nil)
+			t.assert_string("10.0.0.5-10.0.0.77", r.To_s())
+			t.assert(r.Is_ipv4())
+			r, _ = ParseRange(" 2001:db8::1 - 2001:db8::ff ")
+			t.assert_string("2001:db8::1-2001:db8::ff", r.String())
+			t.assert(r.Is_ipv6())
+			r, _ = ParseRange("10.0.0.0/24")
+			t.assert_string("10.0.0.0-10.0.0.255", r.To_s())
+			t.assert(*r == *Parse("10.0.0.77/24").Unwrap().To_range())
+		})
+		t.Run("test_parse_zone", func(t *MyTesting) {
+			r, err := ParseRange("fe80::1%eth0-fe80::ff%eth0")
+			t.assert(err == nil)
+			t.assert_string("fe80::1%eth0-fe80::ff%eth0", r.To_s())
+			r, err = ParseRange("fe80::1%br-lan-fe80::ff%br-lan")
+			t.assert(err == nil)
+			t.assert_string("fe80::1%br-lan-fe80::ff%br-lan", r.To_s())
+			t.assert_string("br-lan", r.Last.Zone)
+			r, err = ParseRange("fe80::1%br-lan - fe80::ff%br-lan")
+			t.assert(err == nil)
+			t.assert_string("fe80::ff%br-lan", r.Last.To_s())
+			_, err = ParseRange("fe80::1%br-lan-fe80::ff%eth0")
+			t.assert(errors.Is(err, ErrBadZone))
+			_, err = ParseRange("fe80::1%br-lan-fe80::fg%br-lan")
+			t.assert(err != nil)
+		})
+		t.Run("test_parse_errors", func(t *MyTesting) {
+			_, err := ParseRange("10.0.0.77-10.0.0.5")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseRange("10.0.0.1-2001:db8::1")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseRange("10.0.0.1-10.0.0.256")
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(16, parse_error_of(err).Offset)
+			_, err = ParseRange("10.0.0.1-10.0.0.9/24")
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = ParseRange("10.0.1-10.0.0.9", ParseOptions{Ipv4: Ipv4Strict})
+			t.assert(errors.Is(err, ErrInvalidAddress))
+		})
+		t.Run("test_new", func(t *MyTesting) {
+			r, err := IPRangeNew(Parse("10.0.0.5/24").Unwrap(), Parse("10.0.0.77/8").Unwrap())
+			t.assert(err == nil)
+			t.assert_string("10.0.0.5/32", r.First.To_string())
+			t.assert_string("10.0.0.77/32", r.Last.To_string())
+		})
+		t.Run("test_size", func(t *MyTesting) {
+			t.assert_bigint(*big.NewInt(73), must_range("10.0.0.5-10.0.0.77").Size())
+			t.assert_bigint(*big.NewInt(1), must_range("10.0.0.5-10.0.0.5").Size())
+			t.assert_bigint(str2Int("340282366920938463463374607431768211456", 10),
+				must_range("::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff").Size())
+		})
+		t.Run("test_to_cidrs", func(t *MyTesting) {
+			t.assert_string_array([]string{"10.0.0.5/32", "10.0.0.6/31", "10.0.0.8/29",
+				"10.0.0.16/28", "10.0.0.32/27", "10.0.0.64/29", "10.0.0.72/30", "10.0.0.76/31"},
+				to_strings(*must_range("10.0.0.5-10.0.0.77").To_cidrs()))
+			t.assert_string_array([]string{"10.0.0.0/24"},
+				to_strings(*must_range("10.0.0.0-10.0.0.255").To_cidrs()))
+			t.assert_string_array([]string{"0.0.0.0/0"},
+				to_strings(*must_range("0.0.0.0-255.255.255.255").To_cidrs()))
+			t.assert_string_array([]string{"::/0"},
+				to_strings(*must_range("::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff").To_cidrs()))
+			t.assert_string_array([]string{"2001:db8::1/128", "2001:db8::2/127", "2001:db8::4/126"},
+				to_strings(*must_range("2001:db8::1-2001:db8::7").To_cidrs()))
+			t.assert_string_array([]string{"255.255.255.255/32"},
+				to_strings(*must_range("255.255.255.255-255.255.255.255").To_cidrs()))
+		})
+		t.Run("test_cidrs_to_ranges", func(t *MyTesting) {
+			nets := []*IPAddress{
+				Parse("10.0.1.0/24").Unwrap(),
+				Parse("10.0.0.128/26").Unwrap(),
+				Parse("10.0.0.0/25").Unwrap(),
+				Parse("2001:db8::/127").Unwrap(),
+				Parse("2001:db8::2/127").Unwrap(),
+			}
+			t.assert_string_array([]string{"10.0.0.0-10.0.0.191", "10.0.1.0-10.0.1.255", "2001:db8::-2001:db8::3"},
+				range_strings(Cidrs_to_ranges(&nets)))
+		})
+		t.Run("test_merge", func(t *MyTesting) {
+			ranges := []*IPRange{
+				must_range("10.0.0.10-10.0.0.20"),
+				must_range("10.0.0.0-10.0.0.9"),
+				must_range("10.0.0.15-10.0.0.30"),
+				must_range("10.0.0.40-10.0.0.50"),
+				must_range("::1-::2"),
+			}
+			t.assert_string_array([]string{"10.0.0.0-10.0.0.30", "10.0.0.40-10.0.0.50", "::1-::2"},
+				range_strings(Merge_ranges(ranges)))
+			t.assert_string("10.0.0.10-10.0.0.20", ranges[0].To_s())
+			t.assert_string_array([]string{"255.255.255.0-255.255.255.255"},
+				range_strings(Merge_ranges([]*IPRange{must_range("255.255.255.0/24"), must_range("255.255.255.128/25")})))
+		})
+		t.Run("test_includes_overlaps", func(t *MyTesting) {
+			r := must_range("10.0.0.5-10.0.0.77")
+			t.assert(r.Includes(Parse("10.0.0.8/29").Unwrap()))
+			t.assert(r.Includes(Parse("10.0.0.5").Unwrap()))
+			t.assert(!r.Includes(Parse("10.0.0.0/29").Unwrap()))
+			t.assert(!r.Includes(Parse("::ffff:10.0.0.8").Unwrap()))
+			t.assert(r.Includes_range(must_range("10.0.0.6-10.0.0.77")))
+			t.assert(!r.Includes_range(must_range("10.0.0.6-10.0.0.78")))
+			t.assert(r.Overlaps(must_range("10.0.0.77-10.0.0.80")))
+			t.assert(r.Overlaps(must_range("10.0.0.0-10.0.0.5")))
+			t.assert(!r.Overlaps(must_range("10.0.0.78-10.0.0.80")))
+			t.assert(!r.Overlaps(must_range("::-::ffff")))
+		})
+		t.Run("test_each", func(t *MyTesting) {
+			r := must_range("10.0.0.254-10.0.1.1")
+			t.assert_string_array([]string{"10.0.0.254/32", "10.0.0.255/32", "10.0.1.0/32", "10.0.1.1/32"},
+				to_strings(slices.Collect(r.Each_seq())))
+			cnt := 0
+			r.Each(func(*IPAddress) { cnt++ })
+			t.assert_int(4, cnt)
+		})
+	})
+}
@@ -1,8 +1,5 @@
 package ipaddress
 
-import "math/big"
-
-import "fmt"
 import "strconv"
 
 // struct IPv4 {
@@ -58,88 +55,52 @@ import "strconv"
 func From_u32(addr uint32, _prefix uint8) ResultIPAddress {
 	prefix := Prefix32New(_prefix)
 	if prefix.IsErr() {
-		return &Error{prefix.UnwrapErr()}
+		return &Error{prefix.(*PrefixError).err}
 	}
-	big_addr := big.NewInt(int64(addr))
 	return &Ok{&IPAddress{
 		IpBitsV4(),
-		*big_addr,
+		U128FromUint64(uint64(addr)),
 		*prefix.Unwrap(),
-		nil,
-		ipv4_is_private,
-		ipv4_is_loopback,
-		ipv4_to_ipv6}}
+		""}}
+}
+
+func Ipv4New(str string, opts ...ParseOptions) ResultIPAddress {
+	return result_ipaddress(Ipv4NewE(str, opts...))
 }
 
-func Ipv4New(str string) ResultIPAddress {
+func Ipv4NewE(str string, opts ...ParseOptions) (*IPAddress, error) {
+	ip, ip_ofs, netmask, netmask_ofs := split_at_slash_ofs(str)
+	return ipv4_new(str, ip, ip_ofs, netmask, netmask_ofs, parse_options(opts))
+}
+
+func ipv4_new(str string, ip string, ip_ofs int, netmask *string, netmask_ofs int, opts ParseOptions) (*IPAddress, error) {
 	// fmt.Printf("---1\n")
-	ip, netmask := Split_at_slash(str)
-	if !Is_valid_ipv4(ip) {
+	split_u32, err := split_to_u32_mode(ip, opts.Ipv4)
+	if err != nil {
 		// fmt.Printf("---2:%s:%s:%s\n", str, ip, *netmask)
-		tmp := fmt.Sprintf("Invalid IP %s", str)
-		return &Error{&tmp}
+		return nil, err.rebase(str, ip_ofs)
 	}
 	ip_prefix_num := uint8(32)
 	if netmask != nil {
 		//  netmask is defined
-		ipn, err := Parse_netmask_to_prefix(*netmask)
+		ipn, err := parse_netmask(*netmask, 32, opts)
 		if err != nil {
 			// fmt.Printf("---3\n")
-			return &Error{err}
+			return nil, err.rebase(str, netmask_ofs)
 		}
-		ip_prefix_num = *ipn
-		//if ip_prefix.ip_bits.version
+		ip_prefix_num = ipn
 	}
-	ip_prefix := Prefix32New(ip_prefix_num)
-	if ip_prefix == nil {
+	ip_prefix, perr := Prefix32NewE(ip_prefix_num)
+	if perr != nil {
 		// fmt.Printf("---4\n")
-		return &Error{ip_prefix.UnwrapErr()}
-	}
-	split_u32, err := split_to_u32(ip)
-	if err != nil {
-		// fmt.Printf("---5 [%s]\n", err)
-		return &Error{err}
+		return nil, perr.(*ParseError).rebase(str, netmask_ofs)
 	}
 	// fmt.Printf("Ipv4New:%x:%s\n", int64(*split_u32), str)
-	return &Ok{&IPAddress{
+	return &IPAddress{
 		IpBitsV4(),
-		*big.NewInt(int64(*split_u32)),
-		*ip_prefix.Unwrap(),
-		nil,
-		ipv4_is_private,
-		ipv4_is_loopback,
-		ipv4_to_ipv6}}
-}
-
-var ipv4_private_networks_val []*IPAddress
-
-func ipv4_private_networks() *[]*IPAddress {
-	if ipv4_private_networks_val == nil {
-		ipv4_private_networks_val = []*IPAddress{
-			Parse("10.0.0.0/8").Unwrap(),
-			Parse("169.254.0.0/16").Unwrap(),
-			Parse("172.16.0.0/12").Unwrap(),
-			Parse("192.168.0.0/16").Unwrap()}
-	}
-	return &ipv4_private_networks_val
-}
-
-func ipv4_is_private(my *IPAddress) bool {
-	for _, ip := range *ipv4_private_networks() {
-		if ip.Includes(my) {
-			return true
-		}
-	}
-	return false
-}
-
-var ipv4_loopback *IPAddress
-
-func ipv4_is_loopback(my *IPAddress) bool {
-	if ipv4_loopback == nil {
-		ipv4_loopback = Parse("127.0.0.0/8").Unwrap()
-	}
-	return ipv4_loopback.Includes(my)
+		U128FromUint64(uint64(split_u32)),
+		*ip_prefix,
+		""}, nil
 }
 
 func ipv4_to_ipv6(ia *IPAddress) *IPAddress {
@@ -147,10 +108,6 @@ func ipv4_to_ipv6(ia *IPAddress) *IPAddress {
 	ret.Ip_bits = IpBitsV6()
 	ret.Host_address = ia.Host_address
 	ret.Prefix = *Prefix128New(ia.Prefix.Num).Unwrap()
-	ret.Mapped = nil
-	ret.Vt_is_private = ipv6_is_private
-	ret.Vt_is_loopback = ipv6_is_loopback
-	ret.Vt_to_ipv6 = ipv6_to_ipv6
 	return ret
 }
 
@@ -165,7 +122,7 @@ func ipv4_to_ipv6(ia *IPAddress) *IPAddress {
 //	ip.a?
 //	  // => true
 func Is_class_a(my *IPAddress) bool {
-	return my.Is_ipv4() && my.Host_address.Cmp(big.NewInt(0x80000000)) < 0
+	return my.Is_ipv4() && my.Host_address.Cmp(U128FromUint64(0x80000000)) < 0
 }
 
 // Checks whether the ip address belongs to a
@@ -180,8 +137,8 @@ func Is_class_a(my *IPAddress) bool {
 //	  // => true
 func Is_class_b(my *IPAddress) bool {
 	return my.Is_ipv4() &&
-		big.NewInt(0x80000000).Cmp(&my.Host_address) <= 0 &&
-		my.Host_address.Cmp(big.NewInt(0xc0000000)) < 0
+		U128FromUint64(0x80000000).Cmp(my.Host_address) <= 0 &&
+		my.Host_address.Cmp(U128FromUint64(0xc0000000)) < 0
 }
 
 // Checks whether the ip address belongs to a
@@ -196,8 +153,8 @@ func Is_class_b(my *IPAddress) bool {
 //	  // => true
 func Is_class_c(my *IPAddress) bool {
 	return my.Is_ipv4() &&
-		big.NewInt(0xc0000000).Cmp(&my.Host_address) <= 0 &&
-		my.Host_address.Cmp(big.NewInt(0xe0000000)) < 0
+		U128FromUint64(0xc0000000).Cmp(my.Host_address) <= 0 &&
+		my.Host_address.Cmp(U128FromUint64(0xe0000000)) < 0
 }
 
 //  Return the ip address in a format compatible
@@ -237,39 +194,6 @@ func Is_class_c(my *IPAddress) bool {
 //   IPv4::new(format!("{}/{}", IPv4::to_ipv4_str(ip32), prefix))
 // }
 
-//  Creates a new IPv4 object from binary data,
-//  like the one you get from a network stream.
-//
-//  For example, on a network stream the IP 172.16.0.1
-//  is represented with the binary "\254\020\n\001".
-//
-//    ip = IPAddress::IPv4::parse_data "\254\020\n\001"
-//    ip.prefix = 24
-//
-//    ip.to_string
-//      // => "172.16.10.1/24"
-//
-// func self.parse_data(str, prefix=32)
-//   self.new(str.unpack("C4").join(".")+"/// {prefix}")
-// end
-
-//  Extract an IPv4 address from a string and
-//  returns a new object
-//
-//  Example:
-//
-//    str = "foobar172.16.10.1barbaz"
-//    ip = IPAddress::IPv4::extract str
-//
-//    ip.to_s
-//      // => "172.16.10.1"
-//
-// func self.extract(str) {
-//   let re = Regexp::new(r"((25[0-5]|2[0-4]\d|1\d\d|[1-9]\d|\d)\.){3}(25[0-5]|2[0-4]\d|1
-// \d\d|[1-9]\d|\d)")
-//   IPv4::new(.match(str).to_s
-// }
-
 //  Summarization (or aggregation) is the process when two or more
 //  networks are taken together to check if a supernet, including all
 //  and only these networks, exists. If it exists then this supernet
@@ -357,9 +281,8 @@ func Is_class_c(my *IPAddress) bool {
 // Note that classes C, D and E will all have a default
 // prefix of /24 or 255.255.255.0
 func Parse_classful(ip_si string) ResultIPAddress {
-	if !Is_valid_ipv4(ip_si) {
-		tmp := fmt.Sprintf("Invalid IP %s", ip_si)
-		return &Error{&tmp}
+	if _, err := split_to_u32(ip_si); err != nil {
+		return &Error{err}
 	}
 	o_ip := Parse(ip_si)
 	if o_ip.IsErr() {
@@ -399,37 +322,65 @@ func Parse_classful(ip_si string) ResultIPAddress {
 //    return dup.reverse()
 // }
 
-func netmask_to_prefix(nm *big.Int, bits uint8) (*uint8, *string) {
+func netmask_to_prefix(nm U128, bits uint8) (uint8, *ParseError) {
 	prefix := uint8(0)
 	addr := nm
 	in_host_part := true
-	two := big.NewInt(2)
 	for i := uint8(0); i < bits; i++ {
-		bit := big.NewInt(0).Rem(addr, two).Uint64()
+		bit := addr.Lo & 1
 		if in_host_part && bit == 0 {
 			prefix = prefix + 1
 		} else if in_host_part && bit == 1 {
 			in_host_part = false
 		} else if !in_host_part && bit == 0 {
-			err := fmt.Sprintf("this is not a net mask %s", nm)
-			return nil, &err
+			return 0, new_parse_error(ErrNonContiguousMask, nm.String(), 0, "this is not a net mask")
 		}
-		addr.Rsh(addr, 1)
+		addr = addr.Rsh(1)
 	}
 	prefix = bits - prefix
-	return &prefix, nil
+	return prefix, nil
+}
+
+func Parse_netmask_to_prefix(netmask string, opts ...ParseOptions) (*uint8, *string) {
+	ret, err := parse_netmask_to_prefix(netmask, parse_options(opts))
+	if err != nil {
+		return nil, error_string(err)
+	}
+	return &ret, nil
+}
+
+func parse_netmask_to_prefix(netmask string, opts ParseOptions) (uint8, *ParseError) {
+	return parse_netmask(netmask, 0, opts)
 }
 
-func Parse_netmask_to_prefix(netmask string) (*uint8, *string) {
+// parses a prefix length or a netmask of an address with bits
+// bits, 0 accepts the netmasks of both families
+func parse_netmask(netmask string, bits uint8, opts ParseOptions) (uint8, *ParseError) {
+	max_bits := bits
+	if max_bits == 0 {
+		max_bits = 128
+	}
 	is_number, err := strconv.ParseUint(netmask, 10, 64)
 	if err == nil {
-		ret := uint8(is_number)
-		return &ret, nil
+		if opts.Ipv4 == Ipv4Strict && len(netmask) > 1 && netmask[0] == '0' {
+			return 0, new_parse_error(ErrBadNetmask, netmask, 0, "leading zeros are ambiguous")
+		}
+		if is_number > uint64(max_bits) {
+			return 0, new_parse_error(ErrPrefixRange, netmask, 0,
+				"Prefix must be in range 0..%d, got: %s", max_bits, netmask)
+		}
+		return uint8(is_number), nil
+	}
+	my_ip, perr := ParseE(netmask, opts)
+	if perr != nil {
+		return 0, new_parse_error(ErrBadNetmask, netmask, 0, "illegal netmask")
+	}
+	if bits != 0 && my_ip.Ip_bits.Bits != bits {
+		return 0, new_parse_error(ErrBadNetmask, netmask, 0, "netmask of the wrong address family")
 	}
-	my_ip := Parse(netmask)
-	if my_ip.IsErr() {
-		tmp := fmt.Sprintf("illegal netmask %s", netmask)
-		return nil, &tmp
+	ret, nerr := netmask_to_prefix(my_ip.Host_address, my_ip.Ip_bits.Bits)
+	if nerr != nil {
+		return 0, nerr.rebase(netmask, 0)
 	}
-	return netmask_to_prefix(&my_ip.Unwrap().Host_address, my_ip.Unwrap().Ip_bits.Bits)
+	return ret, nil
 }
@@ -2,8 +2,6 @@ package ipaddress
 
 // import "ipaddress"
 import "math/big"
-import "fmt"
-import "strconv"
 
 // /  =Name
 // /
@@ -62,72 +60,50 @@ import "strconv"
 // /
 // /
 func From_str(str string, radix int, prefix uint8) ResultIPAddress {
-	num, err := big.NewInt(0).SetString(str, radix)
-	if !err {
+	num, ok := big.NewInt(0).SetString(str, radix)
+	if !ok {
 		// fmt.Printf("From_str:Err:%s:%d\n", str, radix)
-		tmp := fmt.Sprintf("unparsable %s", str)
-		return &Error{&tmp}
+		return &Error{new_parse_error(ErrInvalidAddress, str, 0, "unparsable")}
 	}
 	// fmt.Printf("From_str:Ok:%s:%s\n", str, num.String())
 	return Ipv6FromInt(num, prefix)
 }
 
-func enhance_if_mapped(ip *IPAddress) ResultIPAddress {
-	// fmt.Printf("real mapped {:x} {:x}", &ip.host_address, ip.host_address.clone().shr(32));
-	if ip.Is_mapped() {
-		// fmt.Printf("eim-1\n")
-		return &Ok{ip}
-	}
-	ipv6_top_96bit := big.NewInt(0).Set(&ip.Host_address)
-	// fmt.Printf("eim-A:%s\n", ip.Host_address.String())
-	ipv6_top_96bit = ipv6_top_96bit.Rsh(ipv6_top_96bit, 32)
-	one := big.NewInt(1)
+// the IPv4 part of a mapped address is derived from the host
+// address on demand (see Mapped), here we only make sure that
+// the prefix leaves room for a valid IPv4 prefix.
+func enhance_if_mapped(ip *IPAddress) (*IPAddress, error) {
+	ipv6_top_96bit := ip.Host_address.Rsh(32)
 	// fmt.Printf("eim-0:%s\n", ipv6_top_96bit.String())
-	if ipv6_top_96bit.Cmp(big.NewInt(0xffff)) == 0 {
-		// fmt.Printf("enhance_if_mapped-1:{}", );
-		// fmt.Printf("eim-B")
-		num := big.NewInt(0).Set(&ip.Host_address)
-		num = num.Rem(num, big.NewInt(0).Lsh(one, 32))
-		if num.Cmp(big.NewInt(0)) == 0 {
-			// fmt.Printf("eim-2\n")
-			return &Ok{ip}
-		}
-		//fmt.Printf("ip:{},{:x}", ip.to_string(), num);
-		ipv4_bits := IpBitsV4()
-		if ipv4_bits.Bits < ip.Prefix.Host_prefix() {
-			// fmt.Printf("enhance_if_mapped-2:{}:{}", ip.to_string(), ip.prefix.host_prefix());
-			tmp := fmt.Sprintf("enhance_if_mapped prefix not ipv4 compatible %d", ip.Prefix.Host_prefix())
-			// fmt.Printf("eim-3\n")
-			return &Error{&tmp}
-		}
-		mapped := From_u32(uint32(num.Uint64()), ipv4_bits.Bits-ip.Prefix.Host_prefix())
-		if mapped.IsErr() {
-			// fm!("enhance_if_mapped-3");
-			// fmt.Printf("eim-4\n")
-			return mapped
-		}
-		// fmt.Printf("real mapped!!!!!={}", mapped.clone().Unwrap().to_string());
-		ip.Mapped = mapped.Unwrap()
+	if ipv6_top_96bit == U128FromUint64(0xffff) &&
+		ip.Host_address.Lo&0xffffffff != 0 &&
+		IpBitsV4().Bits < ip.Prefix.Host_prefix() {
+		// fmt.Printf("enhance_if_mapped-2:{}:{}", ip.to_string(), ip.prefix.host_prefix());
+		return nil, new_parse_error(ErrPrefixRange, ip.To_string(), 0,
+			"prefix not ipv4 compatible %d", ip.Prefix.Host_prefix())
 	}
-	ret := &Ok{ip}
-	// fmt.Printf("eim-5:%d\n", ret.IsOk())
-	return ret
+	return ip, nil
 }
 
 func Ipv6FromInt(adr *big.Int, _prefix uint8) ResultIPAddress {
-	prefix := Prefix128New(_prefix)
-	if prefix.IsErr() {
-		return &Error{prefix.UnwrapErr()}
+	num, err := U128FromBig(adr)
+	if err != nil {
+		return &Error{new_parse_error(ErrInvalidAddress, adr.String(), 0, "%s", *err)}
 	}
-	return enhance_if_mapped(&IPAddress{
+	return Ipv6FromU128(num, _prefix)
+}
+
+func Ipv6FromU128(adr U128, _prefix uint8) ResultIPAddress {
+	prefix, err := Prefix128NewE(_prefix)
+	if err != nil {
+		return &Error{err}
+	}
+	return result_ipaddress(enhance_if_mapped(&IPAddress{
 		IpBitsV6(),
-		*adr,
-		*prefix.Unwrap(),
-		nil,
-		ipv6_is_private,
-		ipv6_is_loopback,
-		ipv6_to_ipv6,
-	})
+		adr,
+		*prefix,
+		"",
+	}))
 }
 
 // /  Creates a new IPv6 address object.
@@ -145,63 +121,51 @@ func Ipv6FromInt(adr *big.Int, _prefix uint8) ResultIPAddress {
 // /
 // /    ip6 = IPAddress "2001:db8::8:800:200c:417a/64"
 // /
+// /  or as colon-hex netmask, which has to be contiguous:
+// /
+// /    ip6 = IPAddress "2001:db8::8:800:200c:417a/ffff:ffff:ffff:ffff::"
+// /
 func Ipv6New(str string) ResultIPAddress {
+	return result_ipaddress(Ipv6NewE(str))
+}
+
+func Ipv6NewE(str string) (*IPAddress, error) {
 	// fmt.Printf("i6-1\n")
-	ip, o_netmask := Split_at_slash(str)
-	if Is_valid_ipv6(ip) {
-		// fmt.Printf("i6-2\n")
-		o_num, err := split_to_num(ip)
-		if err != nil {
-			// fmt.Printf("i6-3 %s\n", err)
-			return &Error{err}
+	ip_zone, ip_ofs, o_netmask, netmask_ofs := split_at_slash_ofs(str)
+	ip, zone, zone_ofs, has_zone := split_zone(ip_zone)
+	if has_zone {
+		if _, err := parse_zone(str, zone, ip_ofs+zone_ofs); err != nil {
+			return nil, err
 		}
-		netmask := uint8(128)
-		if o_netmask != nil {
-			// fmt.Printf("i6-4\n")
-			network := o_netmask
-			num_mask, err := strconv.ParseInt(*network, 10, 16)
-			if err != nil {
-				tmp := fmt.Sprintf("can not parse:%s:%s", *network, err)
-				// fmt.Printf("i6-5 %s\n", tmp)
-				return &Error{&tmp}
-			}
-			netmask = uint8(num_mask)
-		}
-		prefix := Prefix128New(netmask)
-		if prefix.IsErr() {
-			// fmt.Printf("i6-6 %s\n", prefix.UnwrapErr())
-			return &Error{prefix.UnwrapErr()}
+	}
+	o_num, err := split_to_num(ip)
+	if err != nil {
+		// fmt.Printf("i6-3 %s\n", err)
+		return nil, err.rebase(str, ip_ofs)
+	}
+	netmask := uint8(128)
+	if o_netmask != nil {
+		// fmt.Printf("i6-4\n")
+		num_mask, err := parse_netmask(*o_netmask, 128, ParseOptions{})
+		if err != nil {
+			// fmt.Printf("i6-5 %s\n", err)
+			return nil, err.rebase(str, netmask_ofs)
 		}
-		// fmt.Printf("i6-7\n")
-		return enhance_if_mapped(&IPAddress{
-			IpBitsV6(),
-			*o_num,
-			*prefix.Unwrap(),
-			nil,
-			ipv6_is_private,
-			ipv6_is_loopback,
-			ipv6_to_ipv6})
-	} else {
-		tmp := fmt.Sprintf("Invalid IP %s", str)
-		// fmt.Printf("i6-8 %s\n", tmp)
-		return &Error{&tmp}
+		netmask = num_mask
 	}
+	prefix, perr := Prefix128NewE(netmask)
+	if perr != nil {
+		// fmt.Printf("i6-6 %s\n", perr)
+		return nil, perr.(*ParseError).rebase(str, netmask_ofs)
+	}
+	// fmt.Printf("i6-7\n")
+	return enhance_if_mapped(&IPAddress{
+		IpBitsV6(),
+		o_num,
+		*prefix,
+		zone})
 }
 
 func ipv6_to_ipv6(ia *IPAddress) *IPAddress {
 	return ia.Clone()
 }
-
-func ipv6_is_loopback(my *IPAddress) bool {
-	return my.Host_address.Cmp(big.NewInt(1)) == 0
-}
-
-var ipv6_private_str = "fd00::/8"
-var ipv6_private *IPAddress
-
-func ipv6_is_private(my *IPAddress) bool {
-	if ipv6_private == nil {
-		ipv6_private = Parse(ipv6_private_str).Unwrap()
-	}
-	return ipv6_private.Includes(my)
-}
@@ -1,7 +1,5 @@
 package ipaddress
 
-import "math/big"
-
 // import "ipaddress"
 
 // /    The loopback  address is a unicast localhost address. If an
@@ -48,5 +46,5 @@ import "math/big"
 // /      ///  "::1/128"
 // /
 func Ipv6LoopbackNew() *IPAddress {
-	return Ipv6FromInt(big.NewInt(1), 128).Unwrap()
+	return Ipv6FromU128(U128FromUint64(1), 128).Unwrap()
 }
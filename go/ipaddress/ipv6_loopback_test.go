@@ -41,7 +41,7 @@ func TestIpv6Loopback(tx *testing.T) {
 			// fmt.Printf("test_attributes-5\n")
 			t.assert_string(s._str, s.ip.To_string_uncompressed())
 			// fmt.Printf("test_attributes-6\n")
-			t.assert_bigint(s.one, s.ip.Host_address)
+			t.assert_bigint(s.one, *s.ip.Host_address_big())
 		})
 
 		t.Run("ipv6_loopback.test_method_ipv6", func(t *MyTesting) {
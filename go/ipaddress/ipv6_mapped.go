@@ -3,7 +3,6 @@ package ipaddress
 import (
 	"bytes"
 	"fmt"
-	"math/big"
 	"strings"
 )
 
@@ -86,83 +85,109 @@ import (
 // /    ip6.to_string
 // /      ///  "::ffff:13.1.68.3"
 // /
-func Ipv6MappedNew(str string) ResultIPAddress {
-	ip, o_netmask := Split_at_slash(str)
+func Ipv6MappedNew(str string, opts ...ParseOptions) ResultIPAddress {
+	return result_ipaddress(Ipv6MappedNewE(str, opts...))
+}
+
+func Ipv6MappedNewE(str string, opts ...ParseOptions) (*IPAddress, error) {
+	ip_zone, ip_ofs, o_netmask, netmask_ofs := split_at_slash_ofs(str)
+	ip, zone, zone_ofs, has_zone := split_zone(ip_zone)
+	if has_zone {
+		if _, err := parse_zone(str, zone, ip_ofs+zone_ofs); err != nil {
+			return nil, err
+		}
+	}
 	split_colon := strings.Split(ip, ":")
 	if len(split_colon) <= 1 {
 		// fmt.Printf("---1");
-		tmp := fmt.Sprintf("not mapped format-1: %s", str)
-		fmt.Printf("Ipv6MappedNew-1:%s\n", tmp)
-		return &Error{&tmp}
+		return nil, new_parse_error(ErrNotMapped, str, ip_ofs, "not mapped format")
 	}
 	// if split_colon.get(0).Unwrap().len() > 0 {
 	//     // fmt.Printf("---1a");
 	//     return Err(format!("not mapped format-2: {}", string));
 	// }
 	// let mapped: Option<IPAddress> = None;
-	netmask := ""
-	if o_netmask != nil {
-		netmask = fmt.Sprintf("/%s", *o_netmask)
-	}
 	ipv4_str := split_colon[len(split_colon)-1]
-	if Is_valid_ipv4(ipv4_str) {
-		ipv4_str = fmt.Sprintf("%s%s", ipv4_str, netmask)
-		ipv4 := Parse(ipv4_str)
-		if ipv4.IsErr() {
-			// fmt.Printf("Ipv6MappedNew-2:%s\n")
-			return ipv4
-		}
-		//mapped = Some(ipv4.Unwrap());
-		addr := ipv4.Unwrap()
-		ipv6_bits := IpBitsV6()
-		part_mod := ipv6_bits.Part_mod
-		up_addr := *big.NewInt(0).Set(&addr.Host_address)
-		down_addr := *big.NewInt(0).Set(&addr.Host_address)
+	ipv4_ofs := ip_ofs + strings.LastIndex(ip, ":") + 1
+	addr, err := ipv4_new(str, ipv4_str, ipv4_ofs, o_netmask, netmask_ofs, parse_options(opts))
+	if err != nil {
+		// fmt.Printf("Ipv6MappedNew-2:%s\n")
+		return nil, err
+	}
+	ipv6_bits := IpBitsV6()
+	part_mod := uint64(ipv6_bits.Part_mod)
 
-		var rebuild_ipv6 bytes.Buffer
-		colon := ""
-		for i := 0; i < len(split_colon)-1; i++ {
-			rebuild_ipv6.WriteString(colon)
-			rebuild_ipv6.WriteString(split_colon[i])
-			colon = ":"
-		}
+	var rebuild_ipv6 bytes.Buffer
+	colon := ""
+	for i := 0; i < len(split_colon)-1; i++ {
 		rebuild_ipv6.WriteString(colon)
-		// fmt.Printf("1-UP:%s\n", up_addr.String())
-		shr := up_addr.Rsh(&up_addr, uint(IpBitsV6().Part_bits))
-		// fmt.Printf("UP:%s:SHR:%s\n", up_addr.String(), shr.String())
-		// fmt.Printf("DOWN:%s\n", down_addr.String())
-		rebuild_ipv4 := fmt.Sprintf("%x:%x/%d",
-			shr.Rem(shr, &part_mod).Uint64(),
-			down_addr.Rem(&down_addr, &part_mod).Uint64(),
-			ipv6_bits.Bits-addr.Prefix.Host_prefix())
-		rebuild_ipv6.WriteString(rebuild_ipv4)
-		rebuild_ipv6_str := rebuild_ipv6.String()
-		r_ipv6 := Parse(rebuild_ipv6_str)
-		if r_ipv6.IsErr() {
-			// fmt.Printf("---3|{}", &rebuild_ipv6);
-			// fmt.Printf("Ipv6MappedNew-3\n")
-			return r_ipv6
-		}
-		if r_ipv6.Unwrap().Is_mapped() {
-			// fmt.Printf("Ipv6MappedNew-4\n")
-			return r_ipv6
-		}
-		ipv6 := r_ipv6.Unwrap()
-		p96bit := big.NewInt(0).Rsh(&ipv6.Host_address, 32)
-		if big.NewInt(0).Cmp(p96bit) != 0 {
-			// fmt.Printf("---4|%s", &rebuild_ipv6);
-			tmp := fmt.Sprintf("is not a mapped address:%s", rebuild_ipv6_str)
-			// fmt.Printf("Ipv6MappedNew-5:%s:%s\n", tmp, p96bit.String())
-			return &Error{&tmp}
-		}
-		{
-			ipv6_ipv4_str := fmt.Sprintf("::ffff:%s", rebuild_ipv4)
-			r_ipv6 := Parse(ipv6_ipv4_str)
-			// fmt.Printf("Ipv6MappedNew-6:[%s]\n",ipv6_ipv4_str)
-			return r_ipv6
-		}
+		rebuild_ipv6.WriteString(split_colon[i])
+		colon = ":"
+	}
+	rebuild_ipv6.WriteString(colon)
+	shr := addr.Host_address.Rsh(uint(ipv6_bits.Part_bits))
+	// fmt.Printf("UP:%s:SHR:%s\n", addr.Host_address.String(), shr.String())
+	rebuild_ipv4 := fmt.Sprintf("%x:%x/%d",
+		shr.Lo%part_mod,
+		addr.Host_address.Lo%part_mod,
+		ipv6_bits.Bits-addr.Prefix.Host_prefix())
+	rebuild_ipv6.WriteString(rebuild_ipv4)
+	rebuild_ipv6_str := rebuild_ipv6.String()
+	ipv6, err := Ipv6NewE(rebuild_ipv6_str)
+	if err != nil {
+		// fmt.Printf("Ipv6MappedNew-3\n")
+		return nil, err.(*ParseError).rebase(str, ip_ofs)
+	}
+	if ipv6.Is_mapped() {
+		// fmt.Printf("Ipv6MappedNew-4\n")
+		ipv6.Zone = zone
+		return ipv6, nil
+	}
+	p96bit := ipv6.Host_address.Rsh(32)
+	if !p96bit.Is_zero() {
+		// fmt.Printf("Ipv6MappedNew-5:%s\n", p96bit.String())
+		return nil, new_parse_error(ErrNotMapped, str, ip_ofs, "is not a mapped address %q", rebuild_ipv6_str)
+	}
+	// fmt.Printf("Ipv6MappedNew-6:[%s]\n", rebuild_ipv4)
+	ret, err := Ipv6NewE(fmt.Sprintf("::ffff:%s", rebuild_ipv4))
+	if err != nil {
+		return nil, err
+	}
+	ret.Zone = zone
+	return ret, nil
+}
+
+// /  Returns the IPv4 address embedded in an IPv6 address, or nil
+// /  if there is none. Besides mapped addresses this knows the
+// /  well-known NAT64 prefix, 6to4, the Teredo client and ISATAP
+// /  interface identifiers. An IPv4 address returns a clone of
+// /  itself:
+// /
+// /    IPAddress("10.0.0.1/8").Embedded_ipv4().To_string()
+// /      ///  "10.0.0.1/8"
+// /    IPAddress("::ffff:172.16.10.1").Embedded_ipv4().To_s()
+// /      ///  "172.16.10.1"
+// /    IPAddress("2002:c000:204::1").Embedded_ipv4().To_s()
+// /      ///  "192.0.2.4"
+// /    IPAddress("2001:0:4136:e378:8000:63bf:3fff:fdd2").Embedded_ipv4().To_s()
+// /      ///  "192.0.2.45"
+// /
+func (self *IPAddress) Embedded_ipv4() *IPAddress {
+	var ret *IPAddress
+	switch {
+	case self.Is_ipv4():
+		ret = self.Clone()
+	case self.Is_mapped():
+		ret = self.Mapped()
+	case self.Is_teredo():
+		teredo, _ := self.Teredo()
+		ret = &teredo.Client
+	case self.Is_6to4():
+		ret, _ = self.Ipv4_6to4()
+	case self.Is_isatap():
+		ret, _ = self.Ipv4_isatap()
+	case Nat64_well_known().Includes(self):
+		ret, _ = self.Nat64_extract(Nat64_well_known())
 	}
-	tmp := fmt.Sprintf("unknown mapped format:[%s]", str)
-	// fmt.Printf("Ipv6MappedNew-7:%s\n", str)
-	return &Error{&tmp}
+	return ret
 }
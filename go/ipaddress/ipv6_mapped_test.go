@@ -56,18 +56,18 @@ func TestIpv6Mapped(tx *testing.T) {
 					fmt.Printf("%s\n", *Parse(ip).UnwrapErr())
 				}
 				t.assert_bool(true, Parse(ip).IsOk())
-				t.assert_bigint(u128, Parse(ip).Unwrap().Host_address)
+				t.assert_bigint(u128, *Parse(ip).Unwrap().Host_address_big())
 			}
 			for ip, u128 := range s.valid_mapped_ipv6 {
 				// fmt.Printf("====%s==%s", ip, u128);
 				t.assert_bool(true, Parse(ip).IsOk())
-				t.assert_bigint(u128, Parse(ip).Unwrap().Host_address)
+				t.assert_bigint(u128, *Parse(ip).Unwrap().Host_address_big())
 			}
 		})
 		t.Run("test_mapped_from_ipv6_conversion", func(t *MyTesting) {
 			for ip6, ip4 := range ipv6MappedSetup().valid_mapped_ipv6_conversion {
 				// fmt.Printf("+%s--%s", ip6, ip4);
-				t.assert_string(ip4, Parse(ip6).Unwrap().Mapped.To_s())
+				t.assert_string(ip4, Parse(ip6).Unwrap().Mapped().To_s())
 			}
 		})
 		t.Run("test_attributes", func(t *MyTesting) {
@@ -77,7 +77,7 @@ func TestIpv6Mapped(tx *testing.T) {
 			t.assert_string(s.s, s.ip.To_s_mapped())
 			t.assert_string(s.sstr, s.ip.To_string_mapped())
 			t.assert_string(s._str, s.ip.To_string_uncompressed())
-			t.assert_bigint(s.u128, s.ip.Host_address)
+			t.assert_bigint(s.u128, *s.ip.Host_address_big())
 		})
 		t.Run("test_method_ipv6", func(t *MyTesting) {
 			s := ipv6MappedSetup().ip
@@ -87,5 +87,20 @@ func TestIpv6Mapped(tx *testing.T) {
 			s := ipv6MappedSetup().ip
 			t.assert(s.Is_mapped())
 		})
+		t.Run("test_embedded_ipv4", func(t *MyTesting) {
+			for ip6, ip4 := range map[string]string{
+				"::ffff:172.16.10.1":                   "172.16.10.1",
+				"2002:c000:204::1":                     "192.0.2.4",
+				"2001:0:4136:e378:8000:63bf:3fff:fdd2": "192.0.2.45",
+				"fe80::200:5efe:808:808":               "8.8.8.8",
+				"64:ff9b::c000:221":                    "192.0.2.33",
+			} {
+				t.assert_string(ip4, Parse(ip6).Unwrap().Embedded_ipv4().To_s())
+			}
+			t.assert(Parse("2001:db8::1").Unwrap().Embedded_ipv4() == nil)
+			ip4 := Parse("10.0.0.1/8").Unwrap()
+			t.assert(*ip4.Embedded_ipv4() == *ip4)
+			t.assert(ip4.Embedded_ipv4() != ip4)
+		})
 	})
 }
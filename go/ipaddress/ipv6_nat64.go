@@ -0,0 +1,119 @@
+package ipaddress
+
+// /  IPv4-embedded IPv6 addresses (RFC 6052) as used by NAT64 and
+// /  DNS64. The IPv4 address is placed right after a NAT64 prefix
+// /  of length 32, 40, 48, 56, 64 or 96, skipping the reserved
+// /  u-octet at bits 64 to 71:
+// /
+// /    prefix = IPAddress("64:ff9b::/96")
+// /
+// /    prefix.Nat64_synthesize(IPAddress("192.0.2.33")).To_s_mixed()
+// /      ///  "64:ff9b::192.0.2.33"
+// /
+// /    prefix = IPAddress("2001:db8:100::/40")
+// /
+// /    prefix.Nat64_synthesize(IPAddress("192.0.2.33")).To_s()
+// /      ///  "2001:db8:1c0:2:21::"
+// /
+
+var nat64_well_known_str = "64:ff9b::/96"
+
+// /  Returns the well-known NAT64 prefix 64:ff9b::/96
+// /
+func Nat64_well_known() *IPAddress {
+	return Parse(nat64_well_known_str).Unwrap()
+}
+
+// /  Checks if prefix is a valid length for a NAT64 prefix
+// /
+func Is_valid_nat64_prefix(prefix uint8) bool {
+	switch prefix {
+	case 32, 40, 48, 56, 64, 96:
+		return true
+	}
+	return false
+}
+
+func (self *IPAddress) nat64_check_prefix() *ParseError {
+	if !self.Is_ipv6() || !Is_valid_nat64_prefix(self.Prefix.Num) {
+		return new_parse_error(ErrPrefixRange, self.To_string(), 0,
+			"NAT64 prefix must be IPv6 with length 32, 40, 48, 56, 64 or 96")
+	}
+	if self.Prefix.Num == 96 && (self.Host_address.Lo>>56)&0xff != 0 {
+		return new_parse_error(ErrInvalidAddress, self.To_string(), 0,
+			"bits 64 to 71 of a NAT64 prefix must be zero")
+	}
+	return nil
+}
+
+// /  Embeds the IPv4 address into the NAT64 prefix self and
+// /  returns the IPv6 host address
+// /
+func (self *IPAddress) Nat64_synthesize(ipv4 *IPAddress) (*IPAddress, error) {
+	if err := self.nat64_check_prefix(); err != nil {
+		return nil, err
+	}
+	if !ipv4.Is_ipv4() {
+		return nil, new_parse_error(ErrInvalidAddress, ipv4.To_string(), 0, "only IPv4 can be embedded")
+	}
+	v4 := ipv4.Host_address.Lo & 0xffffffff
+	ret := self.Network().Host_address
+	if self.Prefix.Num == 96 {
+		ret.Lo |= v4
+	} else {
+		// the bits before the u-octet go to the end of Hi, the
+		// rest follows the u-octet at the top of Lo
+		before := uint(64 - self.Prefix.Num)
+		after := 32 - before
+		ret.Hi |= v4 >> after
+		ret.Lo |= (v4 & (1<<after - 1)) << (56 - after)
+	}
+	return &IPAddress{IpBitsV6(), ret, *Prefix128New(128).Unwrap(), ""}, nil
+}
+
+// /  Extracts the embedded IPv4 address of self, which has to be
+// /  within the NAT64 prefix
+// /
+// /    ip = IPAddress("64:ff9b::c000:221")
+// /
+// /    ip.Nat64_extract(Nat64_well_known()).To_s()
+// /      ///  "192.0.2.33"
+// /
+func (self *IPAddress) Nat64_extract(prefix *IPAddress) (*IPAddress, error) {
+	if err := prefix.nat64_check_prefix(); err != nil {
+		return nil, err
+	}
+	if !self.Is_ipv6() || !prefix.Includes(self) {
+		return nil, new_parse_error(ErrNotMapped, self.To_string(), 0,
+			"not within NAT64 prefix %s", prefix.To_string())
+	}
+	v4 := uint64(0)
+	if prefix.Prefix.Num == 96 {
+		v4 = self.Host_address.Lo & 0xffffffff
+	} else {
+		before := uint(64 - prefix.Prefix.Num)
+		after := 32 - before
+		v4 = (self.Host_address.Hi&(1<<before-1))<<after |
+			(self.Host_address.Lo>>(56-after))&(1<<after-1)
+	}
+	return From_u32(uint32(v4), 32).Unwrap(), nil
+}
+
+// /  Returns the address in mixed notation, the last 32 bits
+// /  written as dotted decimal
+// /
+// /    ip = IPAddress("64:ff9b::c000:221")
+// /
+// /    ip.To_s_mixed()
+// /      ///  "64:ff9b::192.0.2.33"
+// /
+func (self *IPAddress) To_s_mixed() string {
+	if self.Is_ipv4() {
+		return self.To_s()
+	}
+	return self.format_s(FormatOptions{Ipv4_tail: true})
+}
+
+func (self *IPAddress) To_string_mixed() string {
+	return self.To_s_mixed() + "/" + self.Prefix.To_s()
+}
@@ -0,0 +1,80 @@
+package ipaddress
+
+import "errors"
+import "testing"
+
+type Nat64Example struct {
+	prefix string
+	ipv6   string
+}
+
+// the examples of RFC 6052 section 2.4 for 192.0.2.33
+var nat64_examples = []Nat64Example{
+	{"2001:db8::/32", "2001:db8:c000:221::"},
+	{"2001:db8:100::/40", "2001:db8:1c0:2:21::"},
+	{"2001:db8:122::/48", "2001:db8:122:c000:2:2100::"},
+	{"2001:db8:122:300::/56", "2001:db8:122:3c0:0:221::"},
+	{"2001:db8:122:344::/64", "2001:db8:122:344:c0:2:2100:0"},
+	{"2001:db8:122:344::/96", "2001:db8:122:344::c000:221"},
+}
+
+func TestIpv6Nat64(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestIpv6Nat64", func(t *MyTesting) {
+		ipv4 := Parse("192.0.2.33").Unwrap()
+		t.Run("test_synthesize", func(t *MyTesting) {
+			for _, e := range nat64_examples {
+				ip, err := Parse(e.prefix).Unwrap().Nat64_synthesize(ipv4)
+				t.assert(err == nil)
+				t.assert(*ip == *Parse(e.ipv6).Unwrap())
+			}
+		})
+		t.Run("test_extract", func(t *MyTesting) {
+			for _, e := range nat64_examples {
+				ip, err := Parse(e.ipv6).Unwrap().Nat64_extract(Parse(e.prefix).Unwrap())
+				t.assert(err == nil)
+				t.assert_string("192.0.2.33/32", ip.To_string())
+			}
+		})
+		t.Run("test_well_known", func(t *MyTesting) {
+			ip, _ := Nat64_well_known().Nat64_synthesize(ipv4)
+			t.assert_string("64:ff9b::c000:221", ip.To_s())
+			t.assert_string("64:ff9b::192.0.2.33", ip.To_s_mixed())
+			t.assert_string("64:ff9b::192.0.2.33/128", ip.To_string_mixed())
+			back, _ := ip.Nat64_extract(Nat64_well_known())
+			t.assert(*back == *ipv4)
+		})
+		t.Run("test_mixed_notation", func(t *MyTesting) {
+			ip := Parse("64:ff9b::192.0.2.33").Unwrap()
+			t.assert_string("64:ff9b::c000:221", ip.To_s())
+			ip = Parse("64:ff9b::192.0.2.33/96").Unwrap()
+			t.assert_string("64:ff9b::c000:221/96", ip.To_string())
+			t.assert_string("::192.0.2.33", Parse("::c000:221").Unwrap().To_s_mixed())
+			t.assert_string("1:2:3:4:5:6:192.0.2.33", Parse("1:2:3:4:5:6:c000:221").Unwrap().To_s_mixed())
+			t.assert_string("10.0.0.1", Parse("10.0.0.1").Unwrap().To_s_mixed())
+			_, err := ParseE("64:ff9b::192.0.2.256")
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(17, parse_error_of(err).Offset)
+			_, err = ParseE("64:ff9b::192.0.2")
+			t.assert(err != nil)
+			_, err = Ipv6NewE("64:ff9b::192.0.2")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+		})
+		t.Run("test_errors", func(t *MyTesting) {
+			_, err := Parse("2001:db8::/33").Unwrap().Nat64_synthesize(ipv4)
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Parse("10.0.0.0/8").Unwrap().Nat64_synthesize(ipv4)
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Parse("2001:db8:0:0:ff00::/96").Unwrap().Nat64_synthesize(ipv4)
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = Nat64_well_known().Nat64_synthesize(Parse("::1").Unwrap())
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = Parse("2001:db8::1").Unwrap().Nat64_extract(Nat64_well_known())
+			t.assert(errors.Is(err, ErrNotMapped))
+		})
+		t.Run("test_valid_prefix", func(t *MyTesting) {
+			t.assert(Is_valid_nat64_prefix(96))
+			t.assert(!Is_valid_nat64_prefix(80))
+		})
+	})
+}
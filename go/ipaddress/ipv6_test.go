@@ -1,5 +1,6 @@
 package ipaddress
 
+import "errors"
 import "testing"
 import "math/big"
 import "fmt"
@@ -91,7 +92,7 @@ func TestIpv6(tx *testing.T) {
 
 		t.Run("test_method_to_i", func(t *MyTesting) {
 			for ip, num := range ipv6Setup().valid_ipv6 {
-				t.assert_bigint(num, Parse(ip).Unwrap().Host_address)
+				t.assert_bigint(num, *Parse(ip).Unwrap().Host_address_big())
 			}
 		})
 		// #[test]
@@ -234,9 +235,9 @@ func TestIpv6(tx *testing.T) {
 				Parse("1:1:1:0:0:0:0:1").Unwrap().To_s())
 			t.assert_string("1:0:1::1",
 				Parse("1:0:1:0:0:0:0:1").Unwrap().To_s())
-			t.assert_string("1::1:1:1:2:3:1",
+			t.assert_string("1:0:1:1:1:2:3:1",
 				Parse("1:0:1:1:1:2:3:1").Unwrap().To_s())
-			t.assert_string("1::1:1:0:2:3:1",
+			t.assert_string("1:0:1:1:0:2:3:1",
 				Parse("1:0:1:1::2:3:1").Unwrap().To_s())
 			t.assert_string("1:0:0:1::1",
 				Parse("1:0:0:1:0:0:0:1").Unwrap().To_s())
@@ -383,5 +384,39 @@ func TestIpv6(tx *testing.T) {
 			t.assert_string(s.ip.To_string(),
 				From_str(s.hex, 16, 64).Unwrap().To_string())
 		})
+		t.Run("test_colon_hex_netmask", func(t *MyTesting) {
+			t.assert_string("2001:db8::/64", Parse("2001:db8::/ffff:ffff:ffff:ffff::").Unwrap().To_string())
+			t.assert_string("2001:db8::/0", Parse("2001:db8::/::").Unwrap().To_string())
+			t.assert_string("2001:db8::1/128",
+				Parse("2001:db8::1/ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff").Unwrap().To_string())
+			t.assert_string("fe80::1%eth0/10", Parse("fe80::1%eth0/ffc0::").Unwrap().To_string())
+			t.assert_string("2001:db8::/48",
+				Parse("2001:db8::1/64").Unwrap().Change_netmask("ffff:ffff:ffff::").Unwrap().Network().To_string())
+			for _, num := range []uint8{0, 1, 17, 64, 112, 127, 128} {
+				prefix := Prefix128New(num).Unwrap()
+				back := Parse("::/" + prefix.To_ip_str()).Unwrap()
+				t.assert_int(int(num), int(back.Prefix.Num))
+			}
+		})
+		t.Run("test_netmask_errors", func(t *MyTesting) {
+			_, err := ParseE("2001:db8::/ffff:0:ffff::")
+			t.assert(errors.Is(err, ErrNonContiguousMask))
+			t.assert_int(11, parse_error_of(err).Offset)
+			_, err = ParseE("2001:db8::/255.255.0.0")
+			t.assert(errors.Is(err, ErrBadNetmask))
+			_, err = ParseE("10.0.0.0/ffff::")
+			t.assert(errors.Is(err, ErrBadNetmask))
+			_, err = ParseE("2001:db8::/384")
+			t.assert(errors.Is(err, ErrPrefixRange))
+			t.assert_int(11, parse_error_of(err).Offset)
+			_, err = ParseE("2001:db8::/129")
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = ParseE("2001:db8::/-1")
+			t.assert(errors.Is(err, ErrBadNetmask))
+			_, err = ParseE("10.0.0.0/280")
+			t.assert(errors.Is(err, ErrPrefixRange))
+			t.assert(Parse("2001:db8::/64").Unwrap().Change_netmask("255.255.0.0").IsErr())
+			t.assert(Parse("2001:db8::/64").Unwrap().Change_netmask("200").IsErr())
+		})
 	})
 }
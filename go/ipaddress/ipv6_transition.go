@@ -0,0 +1,244 @@
+package ipaddress
+
+// /  IPv4 addresses embedded by the IPv6 transition mechanisms
+// /
+// /  * Teredo (RFC 4380): 2001:0:<server>:<flags>:<port>:<client>,
+// /    port and client are obfuscated by inverting all bits
+// /  * 6to4 (RFC 3056): 2002:<ipv4>::/48
+// /  * ISATAP (RFC 5214): the interface identifier
+// /    [02]00:5efe:<ipv4> after any /64 prefix
+// /  * 6rd (RFC 5969): the 6rd prefix followed by the low bits
+// /    of the customer edge IPv4 address
+// /
+
+// /  The fields of a Teredo address
+// /
+// /    ip = IPAddress("2001:0:4136:e378:8000:63bf:3fff:fdd2")
+// /
+// /    teredo, _ = ip.Teredo()
+// /    teredo.Server.To_s()
+// /      ///  "65.54.227.120"
+// /    teredo.Client.To_s()
+// /      ///  "192.0.2.45"
+// /    teredo.Port
+// /      ///  40000
+// /    teredo.Flags
+// /      ///  0x8000
+// /
+// /  Port and Client are stored unobfuscated. The flags are kept
+// /  as they are, 0x8000 is the cone bit.
+// /
+type Teredo struct {
+	Server IPAddress
+	Client IPAddress
+	Port   uint16
+	Flags  uint16
+}
+
+// /  The cone flag of the Teredo flags
+// /
+const Teredo_cone uint16 = 0x8000
+
+// /  Checks if the address is within the Teredo prefix 2001::/32
+// /
+func (self *IPAddress) Is_teredo() bool {
+	return self.Is_ipv6() && self.Host_address.Hi>>32 == 0x20010000
+}
+
+// /  Decodes a Teredo address, addresses outside of 2001::/32
+// /  return an error which unwraps to ErrNotMapped
+// /
+func (self *IPAddress) Teredo() (*Teredo, error) {
+	if !self.Is_teredo() {
+		return nil, new_parse_error(ErrNotMapped, self.To_string(), 0, "not a Teredo address")
+	}
+	return &Teredo{
+		*From_u32(uint32(self.Host_address.Hi), 32).Unwrap(),
+		*From_u32(^uint32(self.Host_address.Lo), 32).Unwrap(),
+		^uint16(self.Host_address.Lo >> 32),
+		uint16(self.Host_address.Lo >> 48)}, nil
+}
+
+// /  Encodes the Teredo fields into the IPv6 address, Server and
+// /  Client have to be IPv4
+// /
+// /    teredo = Teredo{IPAddress("65.54.227.120"), IPAddress("192.0.2.45"),
+// /      40000, Teredo_cone}
+// /
+// /    teredo.To_ipv6().To_s()
+// /      ///  "2001:0:4136:e378:8000:63bf:3fff:fdd2"
+// /
+func (self *Teredo) To_ipv6() (*IPAddress, error) {
+	if !self.Server.Is_ipv4() {
+		return nil, new_parse_error(ErrInvalidAddress, self.Server.To_string(), 0, "the Teredo server has to be IPv4")
+	}
+	if !self.Client.Is_ipv4() {
+		return nil, new_parse_error(ErrInvalidAddress, self.Client.To_string(), 0, "the Teredo client has to be IPv4")
+	}
+	host := U128{
+		0x20010000<<32 | self.Server.Host_address.Lo&0xffffffff,
+		uint64(self.Flags)<<48 | uint64(^self.Port)<<32 | ^self.Client.Host_address.Lo&0xffffffff}
+	return Ipv6FromU128(host, 128).Unwrap(), nil
+}
+
+// /  Checks if the address is within the 6to4 prefix 2002::/16
+// /
+func (self *IPAddress) Is_6to4() bool {
+	return self.Is_ipv6() && self.Host_address.Hi>>48 == 0x2002
+}
+
+// /  Returns the IPv4 address of a 6to4 address
+// /
+// /    ip = IPAddress("2002:c000:204::1")
+// /
+// /    ip.Ipv4_6to4().To_s()
+// /      ///  "192.0.2.4"
+// /
+func (self *IPAddress) Ipv4_6to4() (*IPAddress, error) {
+	if !self.Is_6to4() {
+		return nil, new_parse_error(ErrNotMapped, self.To_string(), 0, "not a 6to4 address")
+	}
+	return From_u32(uint32(self.Host_address.Hi>>16), 32).Unwrap(), nil
+}
+
+// /  Returns the 6to4 /48 network of an IPv4 address
+// /
+// /    ip = IPAddress("192.0.2.4")
+// /
+// /    ip.To_6to4().To_string()
+// /      ///  "2002:c000:204::/48"
+// /
+func (self *IPAddress) To_6to4() (*IPAddress, error) {
+	if !self.Is_ipv4() {
+		return nil, new_parse_error(ErrInvalidAddress, self.To_string(), 0, "only IPv4 can be embedded")
+	}
+	host := U128{0x2002<<48 | (self.Host_address.Lo&0xffffffff)<<16, 0}
+	return Ipv6FromU128(host, 48).Unwrap(), nil
+}
+
+// /  Checks if the interface identifier of the address is an
+// /  ISATAP identifier 0000:5efe or 0200:5efe followed by IPv4
+// /
+func (self *IPAddress) Is_isatap() bool {
+	return self.Is_ipv6() && (self.Host_address.Lo>>32)&^0x02000000 == 0x5efe
+}
+
+// /  Returns the IPv4 address of an ISATAP interface identifier
+// /
+// /    ip = IPAddress("fe80::200:5efe:c000:221")
+// /
+// /    ip.Ipv4_isatap().To_s()
+// /      ///  "192.0.2.33"
+// /
+func (self *IPAddress) Ipv4_isatap() (*IPAddress, error) {
+	if !self.Is_isatap() {
+		return nil, new_parse_error(ErrNotMapped, self.To_string(), 0, "not an ISATAP address")
+	}
+	return From_u32(uint32(self.Host_address.Lo), 32).Unwrap(), nil
+}
+
+// /  Creates the ISATAP address of the IPv4 address within the
+// /  prefix self, which must not be longer than 64 bits. The
+// /  universal bit (0200:5efe) is set for global IPv4 addresses.
+// /
+// /    prefix = IPAddress("2001:db8::/64")
+// /
+// /    prefix.Isatap_synthesize(IPAddress("192.0.2.33")).To_s()
+// /      ///  "2001:db8::5efe:c000:221"
+// /    prefix.Isatap_synthesize(IPAddress("8.8.8.8")).To_s()
+// /      ///  "2001:db8::200:5efe:808:808"
+// /
+func (self *IPAddress) Isatap_synthesize(ipv4 *IPAddress) (*IPAddress, error) {
+	if !self.Is_ipv6() || self.Prefix.Num > 64 {
+		return nil, new_parse_error(ErrPrefixRange, self.To_string(), 0,
+			"ISATAP prefix must be IPv6 with length up to 64")
+	}
+	if !ipv4.Is_ipv4() {
+		return nil, new_parse_error(ErrInvalidAddress, ipv4.To_string(), 0, "only IPv4 can be embedded")
+	}
+	iid := uint64(0x5efe)
+	if ipv4.Is_global() {
+		iid |= 0x02000000
+	}
+	host := U128{self.Network().Host_address.Hi, iid<<32 | ipv4.Host_address.Lo&0xffffffff}
+	return Ipv6FromU128(host, 128).Unwrap(), nil
+}
+
+// /  A 6rd domain (RFC 5969) given by the 6rd prefix, the number
+// /  of high bits all customer edge IPv4 addresses share and the
+// /  IPv4 address of the border relay, which provides these
+// /  common bits
+// /
+// /    sixrd = SixRdNew(IPAddress("2001:db8::/32"), 8, IPAddress("10.0.0.1"))
+// /
+// /    sixrd.Delegated_prefix(IPAddress("10.100.200.1")).To_string()
+// /      ///  "2001:db8:64c8:100::/56"
+// /
+type SixRd struct {
+	Prefix        IPAddress
+	Ipv4_mask_len uint8
+	Border_relay  IPAddress
+}
+
+func SixRdNew(prefix *IPAddress, ipv4_mask_len uint8, border_relay *IPAddress) (*SixRd, error) {
+	if !prefix.Is_ipv6() {
+		return nil, new_parse_error(ErrInvalidAddress, prefix.To_string(), 0, "the 6rd prefix has to be IPv6")
+	}
+	if !border_relay.Is_ipv4() {
+		return nil, new_parse_error(ErrInvalidAddress, border_relay.To_string(), 0, "the border relay has to be IPv4")
+	}
+	if ipv4_mask_len > 32 || int(prefix.Prefix.Num)+32-int(ipv4_mask_len) > 128 {
+		return nil, new_parse_error(ErrPrefixRange, prefix.To_string(), 0,
+			"the delegated prefix would be longer than 128 bits")
+	}
+	return &SixRd{*prefix.Network(), ipv4_mask_len, *border_relay}, nil
+}
+
+// number of IPv4 bits embedded in the delegated prefix
+func (self *SixRd) ipv4_bits() uint8 {
+	return 32 - self.Ipv4_mask_len
+}
+
+func (self *SixRd) delegated_len() uint8 {
+	return self.Prefix.Prefix.Num + self.ipv4_bits()
+}
+
+func (self *SixRd) common_bits(ipv4 uint32) uint32 {
+	return uint32(uint64(ipv4) >> self.ipv4_bits() << self.ipv4_bits())
+}
+
+// /  Returns the delegated IPv6 prefix of the customer edge
+// /  IPv4 address, which has to share the common high bits with
+// /  the border relay
+// /
+func (self *SixRd) Delegated_prefix(ce *IPAddress) (*IPAddress, error) {
+	if !ce.Is_ipv4() {
+		return nil, new_parse_error(ErrInvalidAddress, ce.To_string(), 0, "the CE address has to be IPv4")
+	}
+	v4 := uint32(ce.Host_address.Lo)
+	if self.common_bits(v4) != self.common_bits(uint32(self.Border_relay.Host_address.Lo)) {
+		return nil, new_parse_error(ErrInvalidAddress, ce.To_string(), 0,
+			"the CE address is not within the 6rd domain of %s", self.Border_relay.To_s())
+	}
+	low := U128FromUint64(uint64(v4) & (1<<self.ipv4_bits() - 1))
+	host := self.Prefix.Host_address.Or(low.Lsh(uint(128 - self.delegated_len())))
+	return Ipv6FromU128(host, self.delegated_len()).Unwrap(), nil
+}
+
+// /  Returns the customer edge IPv4 address of an address within
+// /  a delegated prefix of the 6rd domain
+// /
+// /    ip = IPAddress("2001:db8:64c8:100::1")
+// /
+// /    sixrd.Ce_ipv4(ip).To_s()
+// /      ///  "10.100.200.1"
+// /
+func (self *SixRd) Ce_ipv4(ip *IPAddress) (*IPAddress, error) {
+	if !ip.Is_ipv6() || !self.Prefix.Includes(ip) {
+		return nil, new_parse_error(ErrNotMapped, ip.To_string(), 0,
+			"not within 6rd prefix %s", self.Prefix.To_string())
+	}
+	low := ip.Host_address.Rsh(uint(128-self.delegated_len())).Lo & (1<<self.ipv4_bits() - 1)
+	v4 := self.common_bits(uint32(self.Border_relay.Host_address.Lo)) | uint32(low)
+	return From_u32(v4, 32).Unwrap(), nil
+}
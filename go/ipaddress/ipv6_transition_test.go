@@ -0,0 +1,98 @@
+package ipaddress
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIpv6Transition(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestIpv6Transition", func(t *MyTesting) {
+		t.Run("test_teredo", func(t *MyTesting) {
+			// the example of RFC 4380 section 4
+			ip := Parse("2001:0:4136:e378:8000:63bf:3fff:fdd2").Unwrap()
+			t.assert(ip.Is_teredo())
+			teredo, err := ip.Teredo()
+			t.assert(err == nil)
+			t.assert_string("65.54.227.120/32", teredo.Server.To_string())
+			t.assert_string("192.0.2.45/32", teredo.Client.To_string())
+			t.assert_int(40000, int(teredo.Port))
+			t.assert(teredo.Flags == Teredo_cone)
+			back, err := teredo.To_ipv6()
+			t.assert(err == nil)
+			t.assert(*back == *ip)
+			teredo = &Teredo{*Parse("192.0.2.1").Unwrap(), *Parse("203.0.113.7").Unwrap(), 3544, 0}
+			back, err = teredo.To_ipv6()
+			t.assert(err == nil)
+			t.assert_string("2001:0:c000:201:0:f227:34ff:8ef8", back.To_s())
+			_, err = Parse("2002::1").Unwrap().Teredo()
+			t.assert(errors.Is(err, ErrNotMapped))
+			teredo.Client = *Parse("::1").Unwrap()
+			_, err = teredo.To_ipv6()
+			t.assert(errors.Is(err, ErrInvalidAddress))
+		})
+		t.Run("test_6to4", func(t *MyTesting) {
+			ip := Parse("2002:c000:204::1").Unwrap()
+			t.assert(ip.Is_6to4())
+			ipv4, err := ip.Ipv4_6to4()
+			t.assert(err == nil)
+			t.assert_string("192.0.2.4/32", ipv4.To_string())
+			net, err := ipv4.To_6to4()
+			t.assert(err == nil)
+			t.assert_string("2002:c000:204::/48", net.To_string())
+			t.assert(net.Includes(ip))
+			_, err = Parse("2001:db8::1").Unwrap().Ipv4_6to4()
+			t.assert(errors.Is(err, ErrNotMapped))
+			_, err = ip.To_6to4()
+			t.assert(errors.Is(err, ErrInvalidAddress))
+		})
+		t.Run("test_isatap", func(t *MyTesting) {
+			prefix := Parse("2001:db8::/64").Unwrap()
+			ip, err := prefix.Isatap_synthesize(Parse("192.0.2.33").Unwrap())
+			t.assert(err == nil)
+			t.assert_string("2001:db8::5efe:c000:221", ip.To_s())
+			t.assert(ip.Is_isatap())
+			ip, err = prefix.Isatap_synthesize(Parse("8.8.8.8").Unwrap())
+			t.assert(err == nil)
+			t.assert_string("2001:db8::200:5efe:808:808", ip.To_s())
+			ipv4, err := ip.Ipv4_isatap()
+			t.assert(err == nil)
+			t.assert_string("8.8.8.8", ipv4.To_s())
+			ipv4, err = Parse("fe80::200:5efe:c000:221").Unwrap().Ipv4_isatap()
+			t.assert(err == nil)
+			t.assert_string("192.0.2.33", ipv4.To_s())
+			t.assert(!Parse("fe80::300:5efe:c000:221").Unwrap().Is_isatap())
+			_, err = Parse("2001:db8::1").Unwrap().Ipv4_isatap()
+			t.assert(errors.Is(err, ErrNotMapped))
+			_, err = Parse("2001:db8::/96").Unwrap().Isatap_synthesize(Parse("8.8.8.8").Unwrap())
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+		t.Run("test_6rd", func(t *MyTesting) {
+			sixrd, err := SixRdNew(Parse("2001:db8::/32").Unwrap(), 8, Parse("10.0.0.1").Unwrap())
+			t.assert(err == nil)
+			net, err := sixrd.Delegated_prefix(Parse("10.100.200.1").Unwrap())
+			t.assert(err == nil)
+			t.assert_string("2001:db8:64c8:100::/56", net.To_string())
+			ipv4, err := sixrd.Ce_ipv4(Parse("2001:db8:64c8:100::1").Unwrap())
+			t.assert(err == nil)
+			t.assert_string("10.100.200.1", ipv4.To_s())
+			_, err = sixrd.Delegated_prefix(Parse("11.100.200.1").Unwrap())
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = sixrd.Ce_ipv4(Parse("2001:db9::1").Unwrap())
+			t.assert(errors.Is(err, ErrNotMapped))
+			// the whole IPv4 address after a /28
+			sixrd, err = SixRdNew(Parse("2001:db0::/28").Unwrap(), 0, Parse("192.0.2.1").Unwrap())
+			t.assert(err == nil)
+			net, err = sixrd.Delegated_prefix(Parse("203.0.113.7").Unwrap())
+			t.assert(err == nil)
+			t.assert_string("2001:dbc:b007:1070::/60", net.To_string())
+			ipv4, err = sixrd.Ce_ipv4(net)
+			t.assert(err == nil)
+			t.assert_string("203.0.113.7", ipv4.To_s())
+			_, err = SixRdNew(Parse("2001:db8::/112").Unwrap(), 0, Parse("192.0.2.1").Unwrap())
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = SixRdNew(Parse("10.0.0.0/8").Unwrap(), 0, Parse("192.0.2.1").Unwrap())
+			t.assert(errors.Is(err, ErrInvalidAddress))
+		})
+	})
+}
@@ -25,7 +25,7 @@ func TestIpv6Unspec(tx *testing.T) {
 	t.Run("", func(t *MyTesting) {
 		t.Run("test_attributes", func(t *MyTesting) {
 			s := ipv6UnspecSetup()
-			t.assert_bigint(s.ip.Host_address, s.num)
+			t.assert_bigint(*s.ip.Host_address_big(), s.num)
 			t.assert_uint8(128, s.ip.Prefix.Get_prefix())
 			t.assert_bool(true, s.ip.Is_unspecified())
 			t.assert_string(s.to_s, s.ip.To_s())
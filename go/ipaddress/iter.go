@@ -0,0 +1,154 @@
+package ipaddress
+
+import "context"
+import "iter"
+
+// /  Lazy variants of Each, Each_host, Subnet and Dns_networks.
+// /  Nothing is allocated up front, so they also work for networks
+// /  which are far too large to put into a slice, and a range loop
+// /  over them can be left with break:
+// /
+// /    ip = IPAddress("2001:db8::/32")
+// /
+// /    for net := range ip.Subnet_seq(64) {
+// /      if net.Host_address ... { break }
+// /    }
+// /
+// /  Limit_seq and Context_seq put a guard around any of them.
+// /
+
+// /  Yields the addresses between i and last inclusive
+// /
+func (self *IPAddress) seq_between(i U128, last U128) iter.Seq[*IPAddress] {
+	return func(yield func(*IPAddress) bool) {
+		for i.Cmp(last) <= 0 {
+			if !yield(self.From(i, &self.Prefix)) {
+				return
+			}
+			if i == last {
+				return
+			}
+			i = i.Add(U128FromUint64(1))
+		}
+	}
+}
+
+// /  Yields every address of the network including the network
+// /  and the broadcast address, see Each
+// /
+func (self *IPAddress) Each_seq() iter.Seq[*IPAddress] {
+	return self.seq_between(self.Network().Host_address, self.Broadcast().Host_address)
+}
+
+// /  Yields every host address of the network, see Each_host
+// /
+func (self *IPAddress) Each_host_seq() iter.Seq[*IPAddress] {
+	first, last, ok := self.host_range()
+	if !ok {
+		return func(yield func(*IPAddress) bool) {}
+	}
+	return self.seq_between(first, last)
+}
+
+// /  Yields the subnets with the given prefix, see Subnet
+// /
+// /    ip = IPAddress("172.16.10.0/24")
+// /
+// /    seq, _ := ip.Subnet_seq(26)
+// /      ///  "172.16.10.0/26", "172.16.10.64/26",
+// /      ///  "172.16.10.128/26", "172.16.10.192/26"
+// /
+func (self *IPAddress) Subnet_seq(subprefix uint8) (iter.Seq[*IPAddress], error) {
+	if subprefix < self.Prefix.Num || self.Ip_bits.Bits < subprefix {
+		return nil, new_parse_error(ErrPrefixRange, self.To_string(), 0,
+			"New prefix must be between prefix%d %d and %d",
+			self.Prefix.Num,
+			subprefix,
+			self.Ip_bits.Bits)
+	}
+	net := self.Network()
+	net.Prefix = *net.Prefix.From(subprefix).Unwrap()
+	size := net.Prefix.Host_mask().Add(U128FromUint64(1))
+	last := self.Broadcast().Host_address
+	return func(yield func(*IPAddress) bool) {
+		step := net.Host_address
+		for {
+			if !yield(net.From(step, &net.Prefix)) {
+				return
+			}
+			next := step.Add(size)
+			if next.Cmp(step) <= 0 || next.Cmp(last) > 0 {
+				return
+			}
+			step = next
+		}
+	}, nil
+}
+
+// /  Yields the networks on the DNS delegation boundaries,
+// /  see Dns_networks
+// /
+func (self *IPAddress) Dns_networks_seq() iter.Seq[*IPAddress] {
+	// +self.ip_bits.dns_bits-1
+	next_bit_mask := self.Ip_bits.Bits -
+		(((self.Prefix.Host_prefix()) / self.Ip_bits.Dns_bits) * self.Ip_bits.Dns_bits)
+	// dns_bits
+	step_bit_net := U128FromUint64(1).Lsh(uint(self.Ip_bits.Bits - next_bit_mask))
+	if next_bit_mask <= 0 || step_bit_net.Is_zero() {
+		return func(yield func(*IPAddress) bool) {
+			yield(self.Network())
+		}
+	}
+	resPrefix := self.Prefix.From(next_bit_mask).Unwrap()
+	return func(yield func(*IPAddress) bool) {
+		step := self.Network().Host_address
+		baddr := self.Broadcast().Host_address
+		for baddr.Cmp(step) >= 0 {
+			if !yield(self.From(step, resPrefix)) {
+				return
+			}
+			next := step.Add(step_bit_net)
+			if next.Cmp(step) <= 0 {
+				return
+			}
+			step = next
+		}
+	}
+}
+
+// /  Stops seq after max addresses
+// /
+// /    for ip := range Limit_seq(IPAddress("::/0").Each_seq(), 1000) {
+// /      ...
+// /    }
+// /
+func Limit_seq(seq iter.Seq[*IPAddress], max uint64) iter.Seq[*IPAddress] {
+	return func(yield func(*IPAddress) bool) {
+		if max == 0 {
+			return
+		}
+		cnt := uint64(0)
+		for ip := range seq {
+			if !yield(ip) {
+				return
+			}
+			cnt++
+			if cnt >= max {
+				return
+			}
+		}
+	}
+}
+
+// /  Stops seq as soon as ctx is done, ctx.Err() tells
+// /  the caller if the sequence was cut short
+// /
+func Context_seq(ctx context.Context, seq iter.Seq[*IPAddress]) iter.Seq[*IPAddress] {
+	return func(yield func(*IPAddress) bool) {
+		for ip := range seq {
+			if ctx.Err() != nil || !yield(ip) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package ipaddress
+
+import "context"
+import "errors"
+import "slices"
+import "testing"
+
+func to_strings(ips []*IPAddress) []string {
+	ret := make([]string, len(ips))
+	for i, ip := range ips {
+		ret[i] = ip.To_string()
+	}
+	return ret
+}
+
+func TestIter(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestIter", func(t *MyTesting) {
+		t.Run("test_each_seq", func(t *MyTesting) {
+			ip := Parse("10.0.0.1/30").Unwrap()
+			t.assert_string_array([]string{"10.0.0.0/30", "10.0.0.1/30", "10.0.0.2/30", "10.0.0.3/30"},
+				to_strings(slices.Collect(ip.Each_seq())))
+			t.assert_string_array([]string{"10.0.0.1/30", "10.0.0.2/30"},
+				to_strings(slices.Collect(ip.Each_host_seq())))
+		})
+		t.Run("test_each_seq_break", func(t *MyTesting) {
+			ip := Parse("2001:db8::/64").Unwrap()
+			cnt := 0
+			for range ip.Each_seq() {
+				cnt++
+				if cnt == 3 {
+					break
+				}
+			}
+			t.assert_int(3, cnt)
+		})
+		t.Run("test_each_seq_end_of_space", func(t *MyTesting) {
+			ip := Parse("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127").Unwrap()
+			t.assert_int(2, len(slices.Collect(ip.Each_seq())))
+		})
+		t.Run("test_each_host_seq_bounds", func(t *MyTesting) {
+			hosts := func(str string) int {
+				return len(slices.Collect(Limit_seq(Parse(str).Unwrap().Each_host_seq(), 10)))
+			}
+			all_ones := "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"
+			ip := Parse("0.0.0.0/32").Unwrap()
+			t.assert_string("0.0.0.0/32", ip.Last().To_string())
+			t.assert_int(0, hosts("0.0.0.0/32"))
+			ip = Parse("255.255.255.255/32").Unwrap()
+			t.assert(ip.First().Host_address == U128FromUint64(0xffffffff))
+			t.assert_string("255.255.255.255/32", ip.First().To_string())
+			t.assert_int(0, hosts("255.255.255.255/32"))
+			ip = Parse("::/128").Unwrap()
+			t.assert_string("::/128", ip.Last().To_string())
+			t.assert_int(0, hosts("::/128"))
+			ip = Parse(all_ones + "/128").Unwrap()
+			t.assert_string(all_ones+"/128", ip.First().To_string())
+			t.assert_string(all_ones+"/128", ip.Last().To_string())
+			t.assert_int(0, hosts(all_ones+"/128"))
+			t.assert_int(0, hosts("0.0.0.0/31"))
+			t.assert_int(2, hosts("255.255.255.252/30"))
+			count := 0
+			Parse("0.0.0.0/32").Unwrap().Each_host(func(*IPAddress) { count++ })
+			t.assert_int(0, count)
+		})
+		t.Run("test_subnet_seq", func(t *MyTesting) {
+			seq, err := Parse("172.16.10.0/24").Unwrap().Subnet_seq(26)
+			t.assert(err == nil)
+			t.assert_string_array([]string{"172.16.10.0/26", "172.16.10.64/26", "172.16.10.128/26", "172.16.10.192/26"},
+				to_strings(slices.Collect(seq)))
+			seq, _ = Parse("::/0").Unwrap().Subnet_seq(0)
+			t.assert_string_array([]string{"::/0"}, to_strings(slices.Collect(seq)))
+			_, err = Parse("172.16.10.0/24").Unwrap().Subnet_seq(23)
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+		t.Run("test_subnet_seq_huge", func(t *MyTesting) {
+			seq, err := Parse("2001:db8::/32").Unwrap().Subnet_seq(64)
+			t.assert(err == nil)
+			t.assert_string_array([]string{"2001:db8::/64", "2001:db8:0:1::/64"},
+				to_strings(slices.Collect(Limit_seq(seq, 2))))
+			_, err = Parse("2001:db8::/32").Unwrap().SubnetE(64)
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+		t.Run("test_dns_networks_seq", func(t *MyTesting) {
+			ip := Parse("10.0.0.0/23").Unwrap()
+			t.assert_string_array([]string{"10.0.0.0/24", "10.0.1.0/24"},
+				to_strings(slices.Collect(ip.Dns_networks_seq())))
+			t.assert_string_array(to_strings(ip.Dns_networks()),
+				to_strings(slices.Collect(ip.Dns_networks_seq())))
+		})
+		t.Run("test_limit_seq", func(t *MyTesting) {
+			ip := Parse("::/0").Unwrap()
+			t.assert_int(5, len(slices.Collect(Limit_seq(ip.Each_seq(), 5))))
+			t.assert_int(0, len(slices.Collect(Limit_seq(ip.Each_seq(), 0))))
+			t.assert_int(4, len(slices.Collect(Limit_seq(Parse("10.0.0.0/30").Unwrap().Each_seq(), 10))))
+		})
+		t.Run("test_context_seq", func(t *MyTesting) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			cnt := 0
+			for range Context_seq(ctx, Parse("::/0").Unwrap().Each_seq()) {
+				cnt++
+				if cnt == 10 {
+					cancel()
+				}
+			}
+			t.assert_int(10, cnt)
+			t.assert(ctx.Err() != nil)
+		})
+	})
+}
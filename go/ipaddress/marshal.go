@@ -0,0 +1,178 @@
+package ipaddress
+
+import "encoding/json"
+
+// /  IPAddress and Prefix implement encoding.TextMarshaler,
+// /  json.Marshaler and encoding.BinaryMarshaler (which is also
+// /  used by encoding/gob) and the matching unmarshalers.
+// /
+// /  The text form of an IPAddress is its CIDR string, the binary
+// /  form are the 4 or 16 address bytes followed by the prefix
+// /  length, so 5 bytes for IPv4 and 17 bytes for IPv6. The zone
+// /  of an IPv6 address follows as plain bytes. A mapped IPv6
+// /  address stays a mapped IPv6 address in both forms:
+// /
+// /    ip := Parse("::ffff:10.0.0.1").Unwrap()
+// /    text, _ := ip.MarshalText()
+// /      ///  "::ffff:a00:1/128"
+// /
+// /  The zero value marshals to an empty text and an empty binary,
+// /  both of which unmarshal back to the zero value.
+// /
+
+func (self IPAddress) MarshalText() ([]byte, error) {
+	if self.Ip_bits == nil {
+		return []byte{}, nil
+	}
+	return []byte(self.To_string()), nil
+}
+
+func (self *IPAddress) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*self = IPAddress{}
+		return nil
+	}
+	ip, err := ParseE(string(text))
+	if err != nil {
+		return err
+	}
+	*self = *ip
+	return nil
+}
+
+func (self IPAddress) MarshalJSON() ([]byte, error) {
+	text, _ := self.MarshalText()
+	return json.Marshal(string(text))
+}
+
+func (self *IPAddress) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return self.UnmarshalText([]byte(text))
+}
+
+func (self IPAddress) MarshalBinary() ([]byte, error) {
+	if self.Ip_bits == nil {
+		return []byte{}, nil
+	}
+	b := self.Host_address.bytes16()
+	ret := make([]byte, 0, 17)
+	if self.Is_ipv4() {
+		ret = append(ret, b[12:]...)
+	} else {
+		ret = append(ret, b[:]...)
+	}
+	ret = append(ret, self.Prefix.Num)
+	return append(ret, self.Zone...), nil
+}
+
+func (self *IPAddress) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		*self = IPAddress{}
+		return nil
+	}
+	if len(data) != 5 && len(data) < 17 {
+		return new_parse_error(ErrInvalidAddress, string(data), 0,
+			"binary form must have 5 or at least 17 bytes, got: %d", len(data))
+	}
+	end := 16
+	if len(data) == 5 {
+		end = 4
+	}
+	ip, err := ipaddress_from_bytes(data[:end], data[end])
+	if err != nil {
+		return err
+	}
+	if len(data) > end+1 {
+		if ip, err = ip.With_zone(string(data[end+1:])); err != nil {
+			return err
+		}
+	}
+	*self = *ip
+	return nil
+}
+
+// /  The text form of a Prefix is the netmask in CIDR notation,
+// /  which carries the ip family:
+// /
+// /    prefix = Prefix32New(24).Unwrap()
+// /    text, _ := prefix.MarshalText()
+// /      ///  "255.255.255.0/24"
+// /
+// /  The binary form are two bytes, the bit length of the family
+// /  (32 or 128) and the prefix length.
+// /
+
+func (self Prefix) MarshalText() ([]byte, error) {
+	if self.IpBits == nil {
+		return []byte{}, nil
+	}
+	return []byte(self.To_ip_str() + "/" + self.To_s()), nil
+}
+
+func (self *Prefix) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*self = Prefix{}
+		return nil
+	}
+	ip, err := ParseE(string(text))
+	if err != nil {
+		return err
+	}
+	*self = ip.Prefix
+	return nil
+}
+
+func (self Prefix) MarshalJSON() ([]byte, error) {
+	text, _ := self.MarshalText()
+	return json.Marshal(string(text))
+}
+
+func (self *Prefix) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return self.UnmarshalText([]byte(text))
+}
+
+func (self Prefix) MarshalBinary() ([]byte, error) {
+	if self.IpBits == nil {
+		return []byte{}, nil
+	}
+	return []byte{self.IpBits.Bits, self.Num}, nil
+}
+
+func (self *Prefix) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		*self = Prefix{}
+		return nil
+	}
+	if len(data) != 2 {
+		return new_parse_error(ErrPrefixRange, string(data), 0,
+			"binary form must have 2 bytes, got: %d", len(data))
+	}
+	var prefix *Prefix
+	var err error
+	switch data[0] {
+	case 32:
+		prefix, err = Prefix32NewE(data[1])
+	case 128:
+		prefix, err = Prefix128NewE(data[1])
+	default:
+		return new_parse_error(ErrPrefixRange, string(data), 0, "unknown bit length %d", data[0])
+	}
+	if err != nil {
+		return err
+	}
+	*self = *prefix
+	return nil
+}
@@ -0,0 +1,120 @@
+package ipaddress
+
+import "bytes"
+import "encoding/gob"
+import "encoding/json"
+import "errors"
+import "testing"
+
+type marshalConfig struct {
+	Name    string
+	Network IPAddress
+	Hosts   []*IPAddress
+	Prefix  Prefix
+}
+
+func TestMarshal(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestMarshal", func(t *MyTesting) {
+		t.Run("test_text", func(t *MyTesting) {
+			for _, str := range []string{"172.16.10.1/24", "2001:db8::8:800:200c:417a/64", "::ffff:a00:1/128"} {
+				ip := Parse(str).Unwrap()
+				text, err := ip.MarshalText()
+				t.assert(err == nil)
+				t.assert_string(str, string(text))
+				var back IPAddress
+				t.assert(back.UnmarshalText(text) == nil)
+				t.assert(back == *ip)
+			}
+			var back IPAddress
+			t.assert(errors.Is(back.UnmarshalText([]byte("10.0.0.256")), ErrBadOctet))
+		})
+		t.Run("test_mapped_round_trip", func(t *MyTesting) {
+			ip := Parse("::ffff:10.0.0.1").Unwrap()
+			var back IPAddress
+			text, _ := ip.MarshalText()
+			back.UnmarshalText(text)
+			t.assert(back.Is_mapped())
+			t.assert_string("10.0.0.1", back.Mapped().To_s())
+			bin, _ := ip.MarshalBinary()
+			back = IPAddress{}
+			back.UnmarshalBinary(bin)
+			t.assert(back.Is_mapped())
+			t.assert(back == *ip)
+		})
+		t.Run("test_binary", func(t *MyTesting) {
+			bin, _ := Parse("10.0.0.1/8").Unwrap().MarshalBinary()
+			t.assert(bytes.Equal([]byte{10, 0, 0, 1, 8}, bin))
+			bin, _ = Parse("2001:db8::1/64").Unwrap().MarshalBinary()
+			t.assert_int(17, len(bin))
+			t.assert_uint8(64, bin[16])
+			var back IPAddress
+			t.assert(back.UnmarshalBinary(bin) == nil)
+			t.assert_string("2001:db8::1/64", back.To_string())
+			t.assert(back.UnmarshalBinary([]byte{1, 2, 3}) != nil)
+			t.assert(errors.Is(back.UnmarshalBinary([]byte{10, 0, 0, 1, 33}), ErrPrefixRange))
+		})
+		t.Run("test_zero_value", func(t *MyTesting) {
+			var zero IPAddress
+			text, _ := zero.MarshalText()
+			t.assert_int(0, len(text))
+			back := *Parse("10.0.0.1").Unwrap()
+			back.UnmarshalText(text)
+			t.assert(back == IPAddress{})
+		})
+		t.Run("test_prefix", func(t *MyTesting) {
+			prefix := Prefix32New(24).Unwrap()
+			text, _ := prefix.MarshalText()
+			t.assert_string("255.255.255.0/24", string(text))
+			var back Prefix
+			t.assert(back.UnmarshalText(text) == nil)
+			t.assert(back == *prefix)
+			prefix = Prefix128New(64).Unwrap()
+			text, _ = prefix.MarshalText()
+			t.assert_string("ffff:ffff:ffff:ffff::/64", string(text))
+			back.UnmarshalText(text)
+			t.assert(back == *prefix)
+			bin, _ := prefix.MarshalBinary()
+			t.assert(bytes.Equal([]byte{128, 64}, bin))
+			back = Prefix{}
+			t.assert(back.UnmarshalBinary(bin) == nil)
+			t.assert(back == *prefix)
+			t.assert(errors.Is(back.UnmarshalBinary([]byte{32, 33}), ErrPrefixRange))
+		})
+		t.Run("test_json", func(t *MyTesting) {
+			cfg := marshalConfig{
+				Name:    "lab",
+				Network: *Parse("10.0.0.0/8").Unwrap(),
+				Hosts:   []*IPAddress{Parse("10.0.0.1").Unwrap(), Parse("::ffff:10.0.0.2").Unwrap()},
+				Prefix:  *Prefix128New(48).Unwrap(),
+			}
+			data, err := json.Marshal(cfg)
+			t.assert(err == nil)
+			t.assert_string(`{"Name":"lab","Network":"10.0.0.0/8","Hosts":["10.0.0.1/32","::ffff:a00:2/128"],`+
+				`"Prefix":"ffff:ffff:ffff::/48"}`, string(data))
+			var back marshalConfig
+			t.assert(json.Unmarshal(data, &back) == nil)
+			t.assert(back.Network == cfg.Network)
+			t.assert(*back.Hosts[0] == *cfg.Hosts[0])
+			t.assert(back.Hosts[1].Is_mapped())
+			t.assert(back.Prefix == cfg.Prefix)
+			t.assert(json.Unmarshal([]byte(`{"Network":"10.0.0.0/33"}`), &back) != nil)
+		})
+		t.Run("test_gob", func(t *MyTesting) {
+			cfg := marshalConfig{
+				Name:    "lab",
+				Network: *Parse("2001:db8::/32").Unwrap(),
+				Hosts:   []*IPAddress{Parse("10.0.0.1").Unwrap(), Parse("::ffff:10.0.0.2").Unwrap()},
+				Prefix:  *Prefix32New(16).Unwrap(),
+			}
+			var buf bytes.Buffer
+			t.assert(gob.NewEncoder(&buf).Encode(cfg) == nil)
+			var back marshalConfig
+			t.assert(gob.NewDecoder(&buf).Decode(&back) == nil)
+			t.assert(back.Network == cfg.Network)
+			t.assert(*back.Hosts[0] == *cfg.Hosts[0])
+			t.assert(*back.Hosts[1] == *cfg.Hosts[1])
+			t.assert(back.Prefix == cfg.Prefix)
+		})
+	})
+}
@@ -0,0 +1,191 @@
+package ipaddress
+
+import "net"
+
+// /  The flags of an IPv6 multicast address ff<flags><scope>::
+// /
+// /  * Rendezvous (R): the address embeds the rendezvous point
+// /    (RFC 3956)
+// /  * Prefix (P): the address is based on a unicast prefix
+// /    (RFC 3306)
+// /  * Transient (T): the address is not permanently assigned
+// /    by IANA
+// /
+type MulticastFlags struct {
+	Rendezvous bool
+	Prefix     bool
+	Transient  bool
+}
+
+// the fields of an IPv6 multicast address
+// ff | flags:4 scope:4 | reserved:4 riid:4 | plen:8 | prefix:64 | group id:32
+func (self *IPAddress) multicast_flags_bits() uint64 {
+	return (self.Host_address.Hi >> 52) & 0xf
+}
+
+func (self *IPAddress) multicast_riid() uint64 {
+	return (self.Host_address.Hi >> 40) & 0xf
+}
+
+func (self *IPAddress) multicast_plen() uint8 {
+	return uint8(self.Host_address.Hi >> 32)
+}
+
+func (self *IPAddress) multicast_prefix() U128 {
+	return U128{self.Host_address.Hi<<32 | self.Host_address.Lo>>32, 0}
+}
+
+func (self *IPAddress) check_ipv6_multicast() error {
+	if !self.Is_ipv6() || !self.Is_multicast() {
+		return new_parse_error(ErrConversion, self.To_string(), 0, "not an IPv6 multicast address")
+	}
+	return nil
+}
+
+// /  Returns the Ethernet address a multicast group is sent to,
+// /  01:00:5e and the low 23 bits for IPv4 (RFC 1112), 33:33 and
+// /  the low 32 bits for IPv6 (RFC 2464)
+// /
+// /    IPAddress("224.0.0.251").Multicast_mac().String()
+// /      ///  "01:00:5e:00:00:fb"
+// /    IPAddress("ff02::1:ff00:1").Multicast_mac().String()
+// /      ///  "33:33:ff:00:00:01"
+// /
+// /  Other addresses return an error which unwraps to
+// /  ErrConversion.
+// /
+func (self *IPAddress) Multicast_mac() (net.HardwareAddr, error) {
+	if !self.Is_multicast() {
+		return nil, new_parse_error(ErrConversion, self.To_string(), 0, "not a multicast address")
+	}
+	low := uint32(self.Host_address.Lo)
+	if self.Is_ipv4() {
+		return net.HardwareAddr{0x01, 0x00, 0x5e, byte(low>>16) & 0x7f, byte(low >> 8), byte(low)}, nil
+	}
+	return net.HardwareAddr{0x33, 0x33, byte(low >> 24), byte(low >> 16), byte(low >> 8), byte(low)}, nil
+}
+
+// /  Returns the solicited-node multicast address (RFC 4291) of
+// /  an IPv6 unicast address, ff02::1:ff00:0/104 with the low
+// /  24 bits of the address. The zone is kept.
+// /
+// /    IPAddress("fe80::2aa:ff:fe28:9c5a%eth0").Solicited_node().To_s()
+// /      ///  "ff02::1:ff28:9c5a%eth0"
+// /
+func (self *IPAddress) Solicited_node() (*IPAddress, error) {
+	if !self.Is_ipv6() || self.Is_multicast() {
+		return nil, new_parse_error(ErrConversion, self.To_string(), 0, "not an IPv6 unicast address")
+	}
+	host := U128{0xff02000000000000, 0x00000001ff000000 | self.Host_address.Lo&0xffffff}
+	ret := Ipv6FromU128(host, 128).Unwrap()
+	ret.Zone = self.Zone
+	return ret, nil
+}
+
+// /  Returns the flags of an IPv6 multicast address, the scope
+// /  is returned by Classify
+// /
+// /    IPAddress("ff7e:140:2001:db8::1").Multicast_flags()
+// /      ///  {Rendezvous: true, Prefix: true, Transient: true}
+// /
+func (self *IPAddress) Multicast_flags() (MulticastFlags, error) {
+	if err := self.check_ipv6_multicast(); err != nil {
+		return MulticastFlags{}, err
+	}
+	flags := self.multicast_flags_bits()
+	return MulticastFlags{flags&4 != 0, flags&2 != 0, flags&1 != 0}, nil
+}
+
+// /  Creates the unicast-prefix-based multicast group of
+// /  RFC 3306 for the network self:
+// /
+// /    ip = IPAddress("2001:db8:beef::/48")
+// /
+// /    ip.Unicast_prefix_group(ScopeGlobal, 0x1234).To_s()
+// /      ///  "ff3e:30:2001:db8:beef::1234"
+// /
+// /  The prefix must not be longer than 64 bits.
+// /
+func (self *IPAddress) Unicast_prefix_group(scope Scope, group_id uint32) (*IPAddress, error) {
+	return self.multicast_group(0x3, 0, scope, group_id)
+}
+
+// /  Creates the embedded-RP multicast group of RFC 3956. Self is
+// /  the rendezvous point with the prefix it is embedded with,
+// /  the last 4 bits of the address are the RP interface ID and
+// /  all other bits after the prefix have to be zero:
+// /
+// /    rp = IPAddress("2001:db8:beef:feed::9/64")
+// /
+// /    rp.Embedded_rp_group(ScopeGlobal, 0x1234).To_s()
+// /      ///  "ff7e:940:2001:db8:beef:feed:0:1234"
+// /
+func (self *IPAddress) Embedded_rp_group(scope Scope, group_id uint32) (*IPAddress, error) {
+	if !self.Is_ipv6() {
+		return nil, new_parse_error(ErrConversion, self.To_string(), 0, "the RP has to be IPv6")
+	}
+	riid := self.Host_address.And(self.Prefix.Host_mask())
+	if riid.Cmp(U128FromUint64(0xf)) > 0 {
+		return nil, new_parse_error(ErrInvalidAddress, self.To_string(), 0,
+			"the RP may only have the interface ID in the last 4 bits after the prefix")
+	}
+	return self.multicast_group(0x7, riid.Lo, scope, group_id)
+}
+
+func (self *IPAddress) multicast_group(flags uint64, riid uint64, scope Scope, group_id uint32) (*IPAddress, error) {
+	if !self.Is_ipv6() {
+		return nil, new_parse_error(ErrConversion, self.To_string(), 0, "the prefix has to be IPv6")
+	}
+	if self.Prefix.Num > 64 || (flags&4 != 0 && self.Prefix.Num == 0) {
+		return nil, new_parse_error(ErrPrefixRange, self.To_string(), 0,
+			"the prefix has to be between 1 and 64, got: %d", self.Prefix.Num)
+	}
+	if scope < 0 || scope > 0xf {
+		return nil, new_parse_error(ErrInvalidAddress, self.To_string(), 0, "scope out of range %d", int(scope))
+	}
+	prefix := self.Network().Host_address.Hi
+	host := U128{
+		0xff<<56 | flags<<52 | uint64(scope)<<48 | riid<<40 | uint64(self.Prefix.Num)<<32 | prefix>>32,
+		prefix<<32 | uint64(group_id)}
+	return Ipv6FromU128(host, 128).Unwrap(), nil
+}
+
+// /  Returns the unicast network of an RFC 3306 group
+// /
+// /    IPAddress("ff3e:30:2001:db8:beef::1234").Unicast_prefix()
+// /      ///  "2001:db8:beef::/48"
+// /
+func (self *IPAddress) Unicast_prefix() (*IPAddress, error) {
+	if err := self.check_ipv6_multicast(); err != nil {
+		return nil, err
+	}
+	if self.multicast_flags_bits()&2 == 0 {
+		return nil, new_parse_error(ErrConversion, self.To_string(), 0, "the P flag is not set")
+	}
+	plen := self.multicast_plen()
+	if plen > 64 {
+		return nil, new_parse_error(ErrPrefixRange, self.To_string(), 0, "prefix length %d is too long", plen)
+	}
+	return Ipv6FromU128(self.multicast_prefix(), plen).Unwrap().Network(), nil
+}
+
+// /  Returns the rendezvous point embedded in an RFC 3956 group
+// /  with the prefix it is embedded with
+// /
+// /    IPAddress("ff7e:940:2001:db8:beef:feed:0:1234").Embedded_rp()
+// /      ///  "2001:db8:beef:feed::9/64"
+// /
+func (self *IPAddress) Embedded_rp() (*IPAddress, error) {
+	if err := self.check_ipv6_multicast(); err != nil {
+		return nil, err
+	}
+	if self.multicast_flags_bits()&7 != 7 {
+		return nil, new_parse_error(ErrConversion, self.To_string(), 0, "the R, P and T flags have to be set")
+	}
+	plen := self.multicast_plen()
+	if plen == 0 || plen > 64 {
+		return nil, new_parse_error(ErrPrefixRange, self.To_string(), 0, "prefix length %d is out of range", plen)
+	}
+	prefix := Ipv6FromU128(self.multicast_prefix(), plen).Unwrap().Network()
+	return prefix.From(prefix.Host_address.Or(U128FromUint64(self.multicast_riid())), &prefix.Prefix), nil
+}
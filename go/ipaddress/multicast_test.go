@@ -0,0 +1,98 @@
+package ipaddress
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMulticast(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestMulticast", func(t *MyTesting) {
+		t.Run("test_multicast_mac", func(t *MyTesting) {
+			mac := func(str string) string {
+				ret, err := Parse(str).Unwrap().Multicast_mac()
+				t.assert(err == nil)
+				return ret.String()
+			}
+			t.assert_string("01:00:5e:00:00:fb", mac("224.0.0.251"))
+			t.assert_string("01:00:5e:7f:ff:fa", mac("239.255.255.250"))
+			t.assert_string("01:00:5e:7f:ff:fa", mac("239.127.255.250"))
+			t.assert_string("33:33:00:00:00:01", mac("ff02::1"))
+			t.assert_string("33:33:ff:28:9c:5a", mac("ff02::1:ff28:9c5a"))
+			_, err := Parse("10.0.0.1").Unwrap().Multicast_mac()
+			t.assert(errors.Is(err, ErrConversion))
+			_, err = Parse("2001:db8::1").Unwrap().Multicast_mac()
+			t.assert(errors.Is(err, ErrConversion))
+		})
+		t.Run("test_solicited_node", func(t *MyTesting) {
+			ret, err := Parse("fe80::2aa:ff:fe28:9c5a%eth0").Unwrap().Solicited_node()
+			t.assert(err == nil)
+			t.assert_string("ff02::1:ff28:9c5a%eth0/128", ret.To_string())
+			ret, err = Parse("2001:db8::1/64").Unwrap().Solicited_node()
+			t.assert(err == nil)
+			t.assert_string("ff02::1:ff00:1", ret.To_s())
+			t.assert(ret.Is_multicast())
+			_, err = Parse("10.0.0.1").Unwrap().Solicited_node()
+			t.assert(errors.Is(err, ErrConversion))
+			_, err = Parse("ff02::1").Unwrap().Solicited_node()
+			t.assert(errors.Is(err, ErrConversion))
+		})
+		t.Run("test_multicast_flags", func(t *MyTesting) {
+			flags, err := Parse("ff7e:140:2001:db8::1").Unwrap().Multicast_flags()
+			t.assert(err == nil)
+			t.assert(flags == MulticastFlags{true, true, true})
+			flags, err = Parse("ff3e:30:2001:db8::1").Unwrap().Multicast_flags()
+			t.assert(err == nil)
+			t.assert(flags == MulticastFlags{false, true, true})
+			flags, err = Parse("ff02::1").Unwrap().Multicast_flags()
+			t.assert(err == nil)
+			t.assert(flags == MulticastFlags{})
+			flags, err = Parse("ff15::1").Unwrap().Multicast_flags()
+			t.assert(err == nil)
+			t.assert(flags == MulticastFlags{false, false, true})
+			_, err = Parse("224.0.0.1").Unwrap().Multicast_flags()
+			t.assert(errors.Is(err, ErrConversion))
+		})
+		t.Run("test_unicast_prefix_group", func(t *MyTesting) {
+			ret, err := Parse("2001:db8:beef::1/48").Unwrap().Unicast_prefix_group(ScopeGlobal, 0x1234)
+			t.assert(err == nil)
+			t.assert_string("ff3e:30:2001:db8:beef::1234", ret.To_s())
+			t.assert(ret.Classify() == Classification{TypeMulticast, ScopeGlobal})
+			prefix, err := ret.Unicast_prefix()
+			t.assert(err == nil)
+			t.assert_string("2001:db8:beef::/48", prefix.To_string())
+			ret, err = Parse("2001:db8:1:2::/64").Unwrap().Unicast_prefix_group(ScopeSiteLocal, 1)
+			t.assert(err == nil)
+			t.assert_string("ff35:40:2001:db8:1:2:0:1", ret.To_s())
+			_, err = Parse("2001:db8::/96").Unwrap().Unicast_prefix_group(ScopeGlobal, 1)
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Parse("10.0.0.0/8").Unwrap().Unicast_prefix_group(ScopeGlobal, 1)
+			t.assert(errors.Is(err, ErrConversion))
+			_, err = Parse("ff02::1").Unwrap().Unicast_prefix()
+			t.assert(errors.Is(err, ErrConversion))
+		})
+		t.Run("test_embedded_rp", func(t *MyTesting) {
+			ret, err := Parse("2001:db8:beef:feed::9/64").Unwrap().Embedded_rp_group(ScopeGlobal, 0x1234)
+			t.assert(err == nil)
+			t.assert_string("ff7e:940:2001:db8:beef:feed:0:1234", ret.To_s())
+			rp, err := ret.Embedded_rp()
+			t.assert(err == nil)
+			t.assert_string("2001:db8:beef:feed::9/64", rp.To_string())
+			// RP interface ID 1 in 1234::/64
+			rp, err = Parse("ff7e:140:1234::abcd").Unwrap().Embedded_rp()
+			t.assert(err == nil)
+			t.assert_string("1234::1/64", rp.To_string())
+			rp, err = Parse("ff78:240:2001:db8::1").Unwrap().Embedded_rp()
+			t.assert(err == nil)
+			t.assert_string("2001:db8::2/64", rp.To_string())
+			_, err = Parse("2001:db8::1:9/64").Unwrap().Embedded_rp_group(ScopeGlobal, 1)
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = Parse("::1/0").Unwrap().Embedded_rp_group(ScopeGlobal, 1)
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = Parse("ff3e:30:2001:db8::1").Unwrap().Embedded_rp()
+			t.assert(errors.Is(err, ErrConversion))
+			_, err = Parse("ff7e:100:2001:db8::1").Unwrap().Embedded_rp()
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+	})
+}
@@ -0,0 +1,120 @@
+package ipaddress
+
+import "strconv"
+import "strings"
+
+// /  Selects how the IPv4 part of an address is read
+// /
+// /  * Ipv4Legacy: the behaviour of the library so far, 1 to 4
+// /    decimal parts, the last part fills the lowest octet.
+// /    "10.1" is 10.0.0.1.
+// /  * Ipv4Strict: exactly four decimal octets without leading
+// /    zeros. "0127.0.0.1" and "10.1" are rejected.
+// /  * Ipv4InetAton: what inet_aton(3) accepts, 1 to 4 parts in
+// /    decimal, octal (leading 0) or hex (leading 0x), the last
+// /    part fills the remaining bytes. "10.1" is 10.0.0.1,
+// /    "0127.0.0.1" is 87.0.0.1 and "0x7f000001" is 127.0.0.1.
+// /
+type Ipv4Mode int
+
+const (
+	Ipv4Legacy Ipv4Mode = iota
+	Ipv4Strict
+	Ipv4InetAton
+)
+
+// /  Options for Parse, Ipv4New, Is_valid_ipv4 and
+// /  Parse_netmask_to_prefix. The zero value selects the
+// /  legacy behaviour.
+// /
+// /    Parse("0127.0.0.1", ParseOptions{Ipv4: Ipv4Strict}).IsErr()
+// /      ///  true
+// /
+type ParseOptions struct {
+	Ipv4 Ipv4Mode
+}
+
+func parse_options(opts []ParseOptions) ParseOptions {
+	if len(opts) == 0 {
+		return ParseOptions{}
+	}
+	return opts[0]
+}
+
+func split_to_u32_mode(addr string, mode Ipv4Mode) (uint32, *ParseError) {
+	switch mode {
+	case Ipv4Strict:
+		return split_to_u32_strict(addr)
+	case Ipv4InetAton:
+		return split_to_u32_inet_aton(addr)
+	}
+	return split_to_u32(addr)
+}
+
+func split_to_u32_strict(addr string) (uint32, *ParseError) {
+	trimmed, lead := trim_ofs(addr)
+	parts, parts_ofs := split_ofs(trimmed, ".")
+	if len(parts) != 4 {
+		return 0, new_parse_error(ErrInvalidAddress, addr, lead+len(trimmed),
+			"IP must have 4 parts, got: %d", len(parts))
+	}
+	ip := uint32(0)
+	for idx, part := range parts {
+		if len(part) > 1 && part[0] == '0' {
+			return 0, new_parse_error(ErrBadOctet, addr, lead+parts_ofs[idx],
+				"leading zeros are ambiguous %q", part)
+		}
+		num, err := parse_ipv4_part(part, addr, lead+parts_ofs[idx])
+		if err != nil {
+			return 0, err
+		}
+		ip = ip<<8 | num
+	}
+	return ip, nil
+}
+
+// parses a number like strtoul with base 0 does
+func parse_inet_aton_part(part string) (uint64, error) {
+	base := 10
+	digits := part
+	if strings.HasPrefix(part, "0x") || strings.HasPrefix(part, "0X") {
+		base = 16
+		digits = part[2:]
+		if digits == "" {
+			// "0x" is zero for inet_aton
+			return 0, nil
+		}
+	} else if len(part) > 1 && part[0] == '0' {
+		base = 8
+		digits = part[1:]
+	}
+	if digits == "" || digits[0] == '+' || digits[0] == '-' {
+		return 0, strconv.ErrSyntax
+	}
+	return strconv.ParseUint(digits, base, 32)
+}
+
+func split_to_u32_inet_aton(addr string) (uint32, *ParseError) {
+	trimmed, lead := trim_ofs(addr)
+	parts, parts_ofs := split_ofs(trimmed, ".")
+	if len(parts) > 4 {
+		return 0, new_parse_error(ErrInvalidAddress, addr, lead+parts_ofs[4], "IP has not the right format")
+	}
+	ip := uint32(0)
+	for idx, part := range parts {
+		num, err := parse_inet_aton_part(part)
+		if err != nil {
+			return 0, new_parse_error(ErrBadOctet, addr, lead+parts_ofs[idx], "IP must contain numbers %q", part)
+		}
+		// the last part fills all the remaining bytes
+		bits := uint(8)
+		if idx == len(parts)-1 {
+			bits = uint(8 * (4 - idx))
+		}
+		if num >= 1<<bits {
+			return 0, new_parse_error(ErrBadOctet, addr, lead+parts_ofs[idx], "IP part too large %q", part)
+		}
+		ip = ip<<bits | uint32(num)
+	}
+	return ip, nil
+}
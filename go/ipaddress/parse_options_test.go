@@ -0,0 +1,72 @@
+package ipaddress
+
+import "errors"
+import "testing"
+
+func TestParseOptions(tx *testing.T) {
+	t := MyTesting{tx}
+	strict := ParseOptions{Ipv4: Ipv4Strict}
+	aton := ParseOptions{Ipv4: Ipv4InetAton}
+	t.Run("TestParseOptions", func(t *MyTesting) {
+		t.Run("test_legacy_default", func(t *MyTesting) {
+			t.assert_string("10.0.0.1/32", Parse("10.1").Unwrap().To_string())
+			t.assert_string("127.0.0.1/32", Parse("0127.0.0.1").Unwrap().To_string())
+			t.assert_string("10.0.0.1/32", Parse("10.1", ParseOptions{}).Unwrap().To_string())
+			t.assert(Is_valid_ipv4("10.1"))
+			t.assert(!Is_valid_ipv4("0x7f.0.0.1"))
+		})
+		t.Run("test_strict", func(t *MyTesting) {
+			t.assert_string("172.16.10.1/24", Parse("172.16.10.1/24", strict).Unwrap().To_string())
+			t.assert_string("0.0.0.0/32", Parse("0.0.0.0", strict).Unwrap().To_string())
+			_, err := ParseE("0127.0.0.1", strict)
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(0, parse_error_of(err).Offset)
+			_, err = ParseE("10.1", strict)
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = Ipv4NewE("10.0.0.01", strict)
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(7, parse_error_of(err).Offset)
+			t.assert(!Is_valid_ipv4("10.1", strict))
+			t.assert(!Is_valid_ipv4("10.0.0.", strict))
+			t.assert(Is_valid_ipv4("10.0.0.1", strict))
+			t.assert(!Is_valid("0127.0.0.1", strict))
+			t.assert(Ipv6MappedNew("::ffff:10.1", strict).IsErr())
+		})
+		t.Run("test_strict_netmask", func(t *MyTesting) {
+			prefix, _ := Parse_netmask_to_prefix("255.255.255.0", strict)
+			t.assert_uint8(24, *prefix)
+			_, err := Parse_netmask_to_prefix("255.255.0255.0", strict)
+			t.assert(err != nil)
+			_, err = Parse_netmask_to_prefix("024", strict)
+			t.assert(err != nil)
+			_, perr := ParseE("10.0.0.1/255.0255.0.0", strict)
+			t.assert(errors.Is(perr, ErrBadNetmask))
+			t.assert(Is_valid_netmask("0255.255.255.0"))
+			t.assert(!Is_valid_netmask("0255.255.255.0", strict))
+		})
+		t.Run("test_inet_aton", func(t *MyTesting) {
+			t.assert_string("87.0.0.1/32", Parse("0127.0.0.1", aton).Unwrap().To_string())
+			t.assert_string("127.0.0.1/32", Parse("0x7f.0.0.1", aton).Unwrap().To_string())
+			t.assert_string("127.0.0.1/32", Parse("0x7f000001", aton).Unwrap().To_string())
+			t.assert_string("127.0.0.1/32", Parse("2130706433", aton).Unwrap().To_string())
+			t.assert_string("10.0.0.1/32", Parse("10.1", aton).Unwrap().To_string())
+			t.assert_string("10.1.0.1/32", Parse("10.1.1", aton).Unwrap().To_string())
+			t.assert_string("10.1.1.0/24", Parse("10.1.256/24", aton).Unwrap().To_string())
+			t.assert_string("0.0.0.0/32", Parse("0", aton).Unwrap().To_string())
+			t.assert(Is_valid_ipv4("0x0a.010.0.1", aton))
+			_, err := ParseE("10.256.0.1", aton)
+			t.assert(errors.Is(err, ErrBadOctet))
+			t.assert_int(3, parse_error_of(err).Offset)
+			_, err = ParseE("10.0.0.08", aton)
+			t.assert(errors.Is(err, ErrBadOctet))
+			_, err = ParseE("10..1", aton)
+			t.assert(errors.Is(err, ErrBadOctet))
+			_, err = ParseE("4294967296", aton)
+			t.assert(errors.Is(err, ErrBadOctet))
+			_, err = ParseE("1.2.3.4.5", aton)
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			prefix, _ := Parse_netmask_to_prefix("0xff.0xff.0.0", aton)
+			t.assert_uint8(16, *prefix)
+		})
+	})
+}
@@ -13,12 +13,12 @@ type ResultPrefix interface {
 type Prefix struct {
 	Num     uint8
 	IpBits  *IpBits
-	Netmask big.Int
-	Vt_from func(prefix *Prefix, n uint8) ResultPrefix
+	Netmask U128
 }
 
 type PrefixError struct {
 	Err *string
+	err error
 }
 
 func (self *PrefixError) IsOk() bool         { return false }
@@ -26,6 +26,11 @@ func (self *PrefixError) IsErr() bool        { return true }
 func (self *PrefixError) Unwrap() *Prefix    { return nil }
 func (self *PrefixError) UnwrapErr() *string { return self.Err }
 
+// /  Returns the error as error value, a *ParseError for errors
+// /  of the parsers, to be tested with errors.Is and errors.As
+// /
+func (self *PrefixError) As_error() error { return self.err }
+
 // func Error(err *string) *ErrorIsh {
 //     return &ErrorIsh{err}
 // }
@@ -39,13 +44,16 @@ func (self *PrefixOk) IsErr() bool        { return false }
 func (self *PrefixOk) Unwrap() *Prefix    { return self.Prefix }
 func (self *PrefixOk) UnwrapErr() *string { return nil }
 
+func result_prefix(prefix *Prefix, err error) ResultPrefix {
+	if err != nil {
+		return &PrefixError{error_string(err), err}
+	}
+	return &PrefixOk{prefix}
+}
+
 func (self *Prefix) Clone() *Prefix {
-	ret := new(Prefix)
-	ret.Num = self.Num
-	ret.IpBits = self.IpBits
-	ret.Netmask = self.Netmask
-	ret.Vt_from = self.Vt_from
-	return ret
+	ret := *self
+	return &ret
 }
 
 func (self *Prefix) Equal(other Prefix) bool {
@@ -74,11 +82,25 @@ func (self *Prefix) Cmp(oth *Prefix) int {
 }
 
 func (self *Prefix) From(num uint8) ResultPrefix {
-	return (self.Vt_from)(self, num)
+	return result_prefix(self.FromE(num))
+}
+
+func (self *Prefix) FromE(num uint8) (*Prefix, error) {
+	if self.IpBits.Version == FamilyV4 {
+		return Prefix32NewE(num)
+	}
+	return Prefix128NewE(num)
 }
 
+// /  Returns the netmask in the notation of its family
+// /
+// /    Prefix32New(24).Unwrap().To_ip_str()
+// /      ///  "255.255.255.0"
+// /    Prefix128New(64).Unwrap().To_ip_str()
+// /      ///  "ffff:ffff:ffff:ffff::"
+// /
 func (self *Prefix) To_ip_str() string {
-	return (self.IpBits.Vt_as_compressed_string)(self.IpBits, &self.Netmask)
+	return (self.IpBits.Vt_as_compressed_string)(self.IpBits, self.Netmask)
 }
 
 func (self *Prefix) Size() *big.Int {
@@ -87,20 +109,16 @@ func (self *Prefix) Size() *big.Int {
 	return my
 }
 
-func New_netmask(prefix uint8, bits uint8) *big.Int {
-	mask := big.NewInt(0)
-	host_prefix := bits - prefix
-	for i := uint8(0); i < prefix; i++ {
-		my := big.NewInt(1)
-		my.Lsh(my, uint(host_prefix+i))
-		mask.Add(mask, my)
-	}
-	return mask
+func New_netmask(prefix uint8, bits uint8) U128 {
+	return u128_ones(bits).Lsh(uint(bits - prefix)).And(u128_ones(bits))
 }
 
-//func (self *Prefix) Netmask() *big.Int {
-//	return &self.Net_mask
-//}
+// /  Returns the netmask as big.Int for callers which
+// /  need arbitrary-precision math
+// /
+func (self *Prefix) Netmask_big() *big.Int {
+	return self.Netmask.Big()
+}
 
 func (self *Prefix) Get_prefix() uint8 {
 	return self.Num
@@ -115,14 +133,8 @@ func (self *Prefix) Get_prefix() uint8 {
 // /    prefix.hostmask
 // /      ///  "0.0.0.255"
 // /
-func (self *Prefix) Host_mask() *big.Int {
-	ret := big.NewInt(0)
-	one := big.NewInt(1)
-	for i := uint8(0); i < (self.IpBits.Bits - self.Num); i++ {
-		ret.Lsh(ret, 1)
-		ret.Add(ret, one)
-	}
-	return ret
+func (self *Prefix) Host_mask() U128 {
+	return self.Netmask.Xor(self.IpBits.All_ones)
 }
 
 // /
@@ -9,18 +9,21 @@ import "fmt"
 // /      ///  64
 // /
 func Prefix128New(num uint8) ResultPrefix {
+	return result_prefix(Prefix128NewE(num))
+}
+
+func Prefix128NewE(num uint8) (*Prefix, error) {
 	if num <= 128 {
 		ipBits := IpBitsV6()
 		bits := ipBits.Bits
-		return &PrefixOk{&Prefix{
+		return &Prefix{
 			num,
 			ipBits,
-			*New_netmask(num, bits),
-			Prefix128From,
-		}}
+			New_netmask(num, bits),
+		}, nil
 	}
-	tmp := fmt.Sprintf("Prefix must be in range 0..128, got: %d", num)
-	return &PrefixError{&tmp}
+	return nil, new_parse_error(ErrPrefixRange, fmt.Sprintf("%d", num), 0,
+		"Prefix must be in range 0..128, got: %d", num)
 }
 
 func Prefix128From(my *Prefix, num uint8) ResultPrefix {
@@ -25,6 +25,11 @@ func TestPrefix128(tx *testing.T) {
 			t.assert(Prefix128New(64).IsOk())
 		})
 
+		t.Run("test_method_to_ip_str", func(t *MyTesting) {
+			t.assert_string("ffff:ffff:ffff:ffff::", Prefix128New(64).Unwrap().To_ip_str())
+			t.assert_string("::", Prefix128New(0).Unwrap().To_ip_str())
+		})
+
 		t.Run("test_method_bits", func(t *MyTesting) {
 			prefix := Prefix128New(64).Unwrap()
 			var str bytes.Buffer
@@ -39,7 +44,7 @@ func TestPrefix128(tx *testing.T) {
 
 		t.Run("test_method_to_u32", func(t *MyTesting) {
 			for num, u128 := range prefix128Setup().u128_hash {
-				t.assert_bigint(u128, Prefix128New(num).Unwrap().Netmask)
+				t.assert_bigint(u128, *Prefix128New(num).Unwrap().Netmask_big())
 			}
 		})
 	})
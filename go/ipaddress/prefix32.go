@@ -15,18 +15,20 @@ func Prefix32From(my *Prefix, num uint8) ResultPrefix {
 // /      ///  "255.255.255.0"
 // /
 func Prefix32New(num uint8) ResultPrefix {
+	return result_prefix(Prefix32NewE(num))
+}
+
+func Prefix32NewE(num uint8) (*Prefix, error) {
 	if num <= 32 {
 		ipBits := IpBitsV4()
 		bits := ipBits.Bits
-		tmp := New_netmask(uint8(num), bits)
-		return &PrefixOk{&Prefix{
+		return &Prefix{
 			uint8(num),
 			ipBits,
-			*tmp,
-			Prefix32From,
+			New_netmask(uint8(num), bits),
 			//vt_to_ip_str: _TO_IP_STR,
-		}}
+		}, nil
 	}
-	tmp := fmt.Sprintf("Prefix must be in range 0..32, got: %d", num)
-	return &PrefixError{&tmp}
+	return nil, new_parse_error(ErrPrefixRange, fmt.Sprintf("%d", num), 0,
+		"Prefix must be in range 0..32, got: %d", num)
 }
@@ -120,7 +120,7 @@ func TestPrefix32(tx *testing.T) {
 			for _, e := range prefix32Setup().octets_hash {
 				pref := e.num
 				prefix := Prefix32New(pref).Unwrap()
-				t.assert_uint16_array(prefix.IpBits.Parts(&prefix.Netmask), e.arr)
+				t.assert_uint16_array(prefix.IpBits.Parts(prefix.Netmask), e.arr)
 			}
 		})
 
@@ -131,7 +131,7 @@ func TestPrefix32(tx *testing.T) {
 				prefix := Prefix32New(pref).Unwrap()
 				for index := 0; index < len(arr); index++ {
 					oct := arr[index]
-					t.assert_uint16(prefix.IpBits.Parts(&prefix.Netmask)[index], oct)
+					t.assert_uint16(prefix.IpBits.Parts(prefix.Netmask)[index], oct)
 				}
 			}
 		})
@@ -2,7 +2,7 @@ package ipaddress
 
 import "testing"
 
-func error(t *MyTesting, node string, l []Rle, r []Rle) {
+func rle_error(t *MyTesting, node string, l []Rle, r []Rle) {
 	llen := len(l)
 	rlen := len(r)
 	len := llen
@@ -30,12 +30,12 @@ func error(t *MyTesting, node string, l []Rle, r []Rle) {
 
 func cmpRle(t *MyTesting, node string, l []Rle, r []Rle) {
 	if len(l) != len(r) {
-		error(t, node, l, r)
+		rle_error(t, node, l, r)
 		return
 	}
 	for i := 0; i < len(l); i++ {
 		if !l[i].Equal(r[i]) {
-			error(t, node, l, r)
+			rle_error(t, node, l, r)
 			return
 		}
 	}
@@ -0,0 +1,164 @@
+package ipaddress
+
+import "sort"
+
+// /  An entry of the IANA IPv4 and IPv6 Special-Purpose Address
+// /  Registries with the attributes of RFC 6890 (updated by
+// /  RFC 8190). Attributes the registry lists as N/A are false.
+// /
+// /  * Source: valid as source address
+// /  * Destination: valid as destination address
+// /  * Forwardable: routers may forward packets with it
+// /  * Global: globally reachable
+// /  * Reserved: reserved-by-protocol, special handling is
+// /    required by the protocol itself
+// /
+type SpecialPurpose struct {
+	Network     IPAddress
+	Name        string
+	Rfc         string
+	Source      bool
+	Destination bool
+	Forwardable bool
+	Global      bool
+	Reserved    bool
+}
+
+// /  The revision of the IANA registries the table is taken from
+// /
+const Special_purpose_registry_version = "2024-10"
+
+func special_purpose(network string, name string, rfc string, source bool, destination bool,
+	forwardable bool, global bool, reserved bool) SpecialPurpose {
+	return SpecialPurpose{*Parse(network).Unwrap(), name, rfc,
+		source, destination, forwardable, global, reserved}
+}
+
+var special_purpose_registry = []SpecialPurpose{
+	special_purpose("0.0.0.0/8", "This network", "RFC 791", true, false, false, false, true),
+	special_purpose("0.0.0.0/32", "This host on this network", "RFC 1122", true, false, false, false, true),
+	special_purpose("10.0.0.0/8", "Private-Use", "RFC 1918", true, true, true, false, false),
+	special_purpose("100.64.0.0/10", "Shared Address Space", "RFC 6598", true, true, true, false, false),
+	special_purpose("127.0.0.0/8", "Loopback", "RFC 1122", false, false, false, false, true),
+	special_purpose("169.254.0.0/16", "Link Local", "RFC 3927", true, true, false, false, true),
+	special_purpose("172.16.0.0/12", "Private-Use", "RFC 1918", true, true, true, false, false),
+	special_purpose("192.0.0.0/24", "IETF Protocol Assignments", "RFC 6890", false, false, false, false, false),
+	special_purpose("192.0.0.0/29", "IPv4 Service Continuity Prefix", "RFC 7335", true, true, true, false, false),
+	special_purpose("192.0.0.8/32", "IPv4 dummy address", "RFC 7600", true, false, false, false, false),
+	special_purpose("192.0.0.9/32", "Port Control Protocol Anycast", "RFC 7723", true, true, true, true, false),
+	special_purpose("192.0.0.10/32", "Traversal Using Relays around NAT Anycast", "RFC 8155", true, true, true, true, false),
+	special_purpose("192.0.0.170/32", "NAT64/DNS64 Discovery", "RFC 8880", false, false, false, false, true),
+	special_purpose("192.0.0.171/32", "NAT64/DNS64 Discovery", "RFC 8880", false, false, false, false, true),
+	special_purpose("192.0.2.0/24", "Documentation (TEST-NET-1)", "RFC 5737", false, false, false, false, false),
+	special_purpose("192.31.196.0/24", "AS112-v4", "RFC 7535", true, true, true, true, false),
+	special_purpose("192.52.193.0/24", "AMT", "RFC 7450", true, true, true, true, false),
+	special_purpose("192.88.99.0/24", "Deprecated (6to4 Relay Anycast)", "RFC 7526", false, false, false, false, false),
+	special_purpose("192.168.0.0/16", "Private-Use", "RFC 1918", true, true, true, false, false),
+	special_purpose("192.175.48.0/24", "Direct Delegation AS112 Service", "RFC 7534", true, true, true, true, false),
+	special_purpose("198.18.0.0/15", "Benchmarking", "RFC 2544", true, true, true, false, false),
+	special_purpose("198.51.100.0/24", "Documentation (TEST-NET-2)", "RFC 5737", false, false, false, false, false),
+	special_purpose("203.0.113.0/24", "Documentation (TEST-NET-3)", "RFC 5737", false, false, false, false, false),
+	special_purpose("240.0.0.0/4", "Reserved", "RFC 1112", false, false, false, false, true),
+	special_purpose("255.255.255.255/32", "Limited Broadcast", "RFC 919", false, true, false, false, true),
+
+	special_purpose("::1/128", "Loopback Address", "RFC 4291", false, false, false, false, true),
+	special_purpose("::/128", "Unspecified Address", "RFC 4291", true, false, false, false, true),
+	special_purpose("::ffff:0:0/96", "IPv4-mapped Address", "RFC 4291", false, false, false, false, true),
+	special_purpose("64:ff9b::/96", "IPv4-IPv6 Translat.", "RFC 6052", true, true, true, true, false),
+	special_purpose("64:ff9b:1::/48", "IPv4-IPv6 Translat.", "RFC 8215", true, true, true, false, false),
+	special_purpose("100::/64", "Discard-Only Address Block", "RFC 6666", true, true, true, false, false),
+	special_purpose("2001::/23", "IETF Protocol Assignments", "RFC 2928", false, false, false, false, false),
+	special_purpose("2001::/32", "TEREDO", "RFC 4380", true, true, true, false, false),
+	special_purpose("2001:1::1/128", "Port Control Protocol Anycast", "RFC 7723", true, true, true, true, false),
+	special_purpose("2001:1::2/128", "Traversal Using Relays around NAT Anycast", "RFC 8155", true, true, true, true, false),
+	special_purpose("2001:1::3/128", "DNS-SD Service Registration Protocol Anycast", "RFC 9665", true, true, true, true, false),
+	special_purpose("2001:2::/48", "Benchmarking", "RFC 5180", true, true, true, false, false),
+	special_purpose("2001:3::/32", "AMT", "RFC 7450", true, true, true, true, false),
+	special_purpose("2001:4:112::/48", "AS112-v6", "RFC 7535", true, true, true, true, false),
+	special_purpose("2001:10::/28", "Deprecated (previously ORCHID)", "RFC 4843", false, false, false, false, false),
+	special_purpose("2001:20::/28", "ORCHIDv2", "RFC 7343", true, true, true, true, false),
+	special_purpose("2001:30::/28", "Drone Remote ID Protocol Entity Tags (DETs) Prefix", "RFC 9374", true, true, true, true, false),
+	special_purpose("2001:db8::/32", "Documentation", "RFC 3849", false, false, false, false, false),
+	special_purpose("2002::/16", "6to4", "RFC 3056", true, true, true, false, false),
+	special_purpose("2620:4f:8000::/48", "Direct Delegation AS112 Service", "RFC 7534", true, true, true, true, false),
+	special_purpose("3fff::/20", "Documentation", "RFC 9637", false, false, false, false, false),
+	special_purpose("5f00::/16", "Segment Routing (SRv6) SIDs", "RFC 9602", true, true, true, false, false),
+	special_purpose("fc00::/7", "Unique-Local", "RFC 4193", true, true, true, false, false),
+	special_purpose("fe80::/10", "Link-Local Unicast", "RFC 4291", true, true, false, false, true),
+}
+
+// /  Returns a copy of the IPv4 and IPv6 special-purpose
+// /  registries, see Special_purpose_registry_version
+// /
+func Special_purpose_registry() []SpecialPurpose {
+	ret := make([]SpecialPurpose, len(special_purpose_registry))
+	copy(ret, special_purpose_registry)
+	return ret
+}
+
+// /  Returns the registry entries which include the address or
+// /  network, the least specific first
+// /
+// /    ip = IPAddress("192.0.0.9")
+// /
+// /    ip.Special_purpose()
+// /      ///  ["192.0.0.0/24" IETF Protocol Assignments,
+// /      ///   "192.0.0.9/32" Port Control Protocol Anycast]
+// /
+func (self *IPAddress) Special_purpose() []SpecialPurpose {
+	ret := []SpecialPurpose{}
+	for _, entry := range special_purpose_registry {
+		if entry.Network.Includes(self) {
+			ret = append(ret, entry)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Network.Prefix.Num < ret[j].Network.Prefix.Num
+	})
+	return ret
+}
+
+// /  Checks if the address is globally reachable, which is true
+// /  for every address not in the special-purpose registries and
+// /  otherwise decided by the most specific entry
+// /
+// /    IPAddress("8.8.8.8").Is_global()
+// /      ///  true
+// /    IPAddress("100.64.0.1").Is_global()
+// /      ///  false
+// /    IPAddress("192.0.0.9").Is_global()
+// /      ///  true
+// /
+func (self *IPAddress) Is_global() bool {
+	entries := self.Special_purpose()
+	if len(entries) == 0 {
+		return true
+	}
+	return entries[len(entries)-1].Global
+}
+
+// /  Checks if the address is reserved for documentation,
+// /  192.0.2.0/24, 198.51.100.0/24, 203.0.113.0/24, 2001:db8::/32
+// /  or 3fff::/20
+// /
+func (self *IPAddress) Is_documentation() bool {
+	return self.has_special_purpose("RFC 5737", "RFC 3849", "RFC 9637")
+}
+
+// /  Checks if the address is reserved for benchmarking,
+// /  198.18.0.0/15 or 2001:2::/48
+// /
+func (self *IPAddress) Is_benchmarking() bool {
+	return self.has_special_purpose("RFC 2544", "RFC 5180")
+}
+
+func (self *IPAddress) has_special_purpose(rfcs ...string) bool {
+	for _, entry := range self.Special_purpose() {
+		for _, rfc := range rfcs {
+			if entry.Rfc == rfc {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package ipaddress
+
+import "testing"
+
+func special_purpose_names(ip string) []string {
+	ret := []string{}
+	for _, entry := range Parse(ip).Unwrap().Special_purpose() {
+		ret = append(ret, entry.Network.To_string()+" "+entry.Name)
+	}
+	return ret
+}
+
+func TestSpecialPurpose(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestSpecialPurpose", func(t *MyTesting) {
+		t.Run("test_registry", func(t *MyTesting) {
+			registry := Special_purpose_registry()
+			t.assert(len(registry) > 40)
+			for _, entry := range registry {
+				t.assert(entry.Network.Network().Host_address == entry.Network.Host_address)
+				t.assert(entry.Rfc != "")
+			}
+			registry[0].Name = "changed"
+			t.assert_string("This network", Special_purpose_registry()[0].Name)
+		})
+		t.Run("test_lookup", func(t *MyTesting) {
+			t.assert_string_array([]string{"100.64.0.0/10 Shared Address Space"},
+				special_purpose_names("100.64.1.2"))
+			t.assert_string_array([]string{"192.0.0.0/24 IETF Protocol Assignments",
+				"192.0.0.9/32 Port Control Protocol Anycast"}, special_purpose_names("192.0.0.9"))
+			t.assert_string_array([]string{"0.0.0.0/8 This network", "0.0.0.0/32 This host on this network"},
+				special_purpose_names("0.0.0.0"))
+			t.assert_string_array([]string{"2001::/23 IETF Protocol Assignments",
+				"2001:20::/28 ORCHIDv2"}, special_purpose_names("2001:20::1"))
+			t.assert_string_array([]string{"198.18.0.0/15 Benchmarking"}, special_purpose_names("198.19.0.0/16"))
+			t.assert_string_array([]string{}, special_purpose_names("198.16.0.0/12"))
+			t.assert_string_array([]string{}, special_purpose_names("8.8.8.8"))
+			t.assert_string_array([]string{"::ffff:0:0/96 IPv4-mapped Address"}, special_purpose_names("::ffff:8.8.8.8"))
+			entries := Parse("169.254.1.1").Unwrap().Special_purpose()
+			t.assert(entries[0].Source && entries[0].Destination && !entries[0].Forwardable &&
+				!entries[0].Global && entries[0].Reserved)
+		})
+		t.Run("test_is_global", func(t *MyTesting) {
+			for _, ip := range []string{"8.8.8.8", "192.0.0.9", "192.31.196.1", "2a00:1450::1",
+				"2001:1::1", "2001:20::1", "64:ff9b::808:808"} {
+				t.assert_bool(true, Parse(ip).Unwrap().Is_global())
+			}
+			for _, ip := range []string{"10.1.2.3", "100.64.0.1", "127.0.0.1", "169.254.0.1", "192.0.2.1",
+				"198.18.0.1", "240.0.0.1", "255.255.255.255", "0.0.0.0", "192.0.0.1", "::1", "::",
+				"::ffff:8.8.8.8", "fe80::1", "fd00::1", "2001:db8::1", "2001::1", "2002::1", "100::1", "3fff::1"} {
+				t.assert_bool(false, Parse(ip).Unwrap().Is_global())
+			}
+		})
+		t.Run("test_predicates", func(t *MyTesting) {
+			t.assert(Parse("192.0.2.1").Unwrap().Is_documentation())
+			t.assert(Parse("198.51.100.0/24").Unwrap().Is_documentation())
+			t.assert(Parse("203.0.113.7").Unwrap().Is_documentation())
+			t.assert(Parse("2001:db8::1").Unwrap().Is_documentation())
+			t.assert(Parse("3fff::/32").Unwrap().Is_documentation())
+			t.assert(!Parse("192.0.3.1").Unwrap().Is_documentation())
+			t.assert(Parse("198.19.255.255").Unwrap().Is_benchmarking())
+			t.assert(Parse("2001:2::1").Unwrap().Is_benchmarking())
+			t.assert(!Parse("198.20.0.0").Unwrap().Is_benchmarking())
+		})
+		t.Run("test_is_private_unchanged", func(t *MyTesting) {
+			t.assert(Parse("169.254.1.1").Unwrap().Is_private())
+			t.assert(!Parse("100.64.0.1").Unwrap().Is_private())
+			t.assert(Parse("fd00::1").Unwrap().Is_private())
+		})
+		t.Run("test_is_private_unique_local", func(t *MyTesting) {
+			for _, ip := range []string{"fc00::1", "fd00::1", "fdff::1", "fc00::/7", "fd12:3456::/48"} {
+				ip := Parse(ip).Unwrap()
+				t.assert(ip.Is_private())
+				t.assert_bool(ip.Is_unique_local(), ip.Is_private())
+			}
+			for _, ip := range []string{"fe00::1", "fbff::1", "fc00::/6"} {
+				t.assert(!Parse(ip).Unwrap().Is_private())
+			}
+		})
+	})
+}
@@ -0,0 +1,196 @@
+package ipaddress
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// /  U128 is the fixed width storage for every address and netmask.
+// /
+// /  An IPv6 address uses all 128 bits, an IPv4 address lives in the
+// /  low 32 bits of Lo. All operations are value based and do not
+// /  allocate, which keeps the hot paths like Includes, Network and
+// /  Cmp free of any arbitrary-precision math.
+// /
+// /    U128New(0, 0x0a000001).Text(16)
+// /      ///  "a000001"
+// /
+type U128 struct {
+	Hi uint64
+	Lo uint64
+}
+
+func U128New(hi uint64, lo uint64) U128 {
+	return U128{hi, lo}
+}
+
+func U128FromUint64(lo uint64) U128 {
+	return U128{0, lo}
+}
+
+// /  Converts a big.Int into the fixed width representation.
+// /  Fails if the number is negative or does not fit into 128 bits.
+// /
+func U128FromBig(num *big.Int) (U128, *string) {
+	if num.Sign() < 0 || num.BitLen() > 128 {
+		tmp := fmt.Sprintf("number does not fit in 128 bits %s", num.String())
+		return U128{}, &tmp
+	}
+	lo := big.NewInt(0).And(num, big.NewInt(0).SetUint64(^uint64(0)))
+	hi := big.NewInt(0).Rsh(num, 64)
+	return U128{hi.Uint64(), lo.Uint64()}, nil
+}
+
+// /  Returns the value as a newly allocated big.Int
+// /
+func (self U128) Big() *big.Int {
+	ret := big.NewInt(0).SetUint64(self.Hi)
+	ret.Lsh(ret, 64)
+	return ret.Or(ret, big.NewInt(0).SetUint64(self.Lo))
+}
+
+// /  Returns the lower 64 bits
+// /
+func (self U128) Uint64() uint64 {
+	return self.Lo
+}
+
+func (self U128) Is_zero() bool {
+	return self.Hi == 0 && self.Lo == 0
+}
+
+func (self U128) Cmp(oth U128) int {
+	if self.Hi < oth.Hi {
+		return -1
+	} else if self.Hi > oth.Hi {
+		return 1
+	}
+	if self.Lo < oth.Lo {
+		return -1
+	} else if self.Lo > oth.Lo {
+		return 1
+	}
+	return 0
+}
+
+// /  Adds oth, wrapping around on overflow
+// /
+func (self U128) Add(oth U128) U128 {
+	lo, carry := bits.Add64(self.Lo, oth.Lo, 0)
+	hi, _ := bits.Add64(self.Hi, oth.Hi, carry)
+	return U128{hi, lo}
+}
+
+// /  Subtracts oth, wrapping around on underflow
+// /
+func (self U128) Sub(oth U128) U128 {
+	lo, borrow := bits.Sub64(self.Lo, oth.Lo, 0)
+	hi, _ := bits.Sub64(self.Hi, oth.Hi, borrow)
+	return U128{hi, lo}
+}
+
+func (self U128) And(oth U128) U128 {
+	return U128{self.Hi & oth.Hi, self.Lo & oth.Lo}
+}
+
+func (self U128) Or(oth U128) U128 {
+	return U128{self.Hi | oth.Hi, self.Lo | oth.Lo}
+}
+
+func (self U128) Xor(oth U128) U128 {
+	return U128{self.Hi ^ oth.Hi, self.Lo ^ oth.Lo}
+}
+
+func (self U128) Not() U128 {
+	return U128{^self.Hi, ^self.Lo}
+}
+
+// /  Shifts left by n bits, shifts of 128 or more yield zero
+// /
+func (self U128) Lsh(n uint) U128 {
+	switch {
+	case n >= 128:
+		return U128{}
+	case n >= 64:
+		return U128{self.Lo << (n - 64), 0}
+	case n == 0:
+		return self
+	}
+	return U128{self.Hi<<n | self.Lo>>(64-n), self.Lo << n}
+}
+
+// /  Shifts right by n bits, shifts of 128 or more yield zero
+// /
+func (self U128) Rsh(n uint) U128 {
+	switch {
+	case n >= 128:
+		return U128{}
+	case n >= 64:
+		return U128{0, self.Hi >> (n - 64)}
+	case n == 0:
+		return self
+	}
+	return U128{self.Hi >> n, self.Lo>>n | self.Hi<<(64-n)}
+}
+
+// /  Returns the value in the given base without leading zeros,
+// /  like big.Int.Text does.
+// /
+func (self U128) Text(base int) string {
+	if self.Hi == 0 {
+		return strconv.FormatUint(self.Lo, base)
+	}
+	width := 0
+	switch base {
+	case 2:
+		width = 64
+	case 16:
+		width = 16
+	default:
+		return self.Big().Text(base)
+	}
+	lo := strconv.FormatUint(self.Lo, base)
+	return strconv.FormatUint(self.Hi, base) + strings.Repeat("0", width-len(lo)) + lo
+}
+
+func (self U128) String() string {
+	return self.Text(10)
+}
+
+// /  Returns a value with the lower +n+ bits set
+// /
+func u128_ones(n uint8) U128 {
+	return U128{^uint64(0), ^uint64(0)}.Rsh(uint(128 - uint(n)))
+}
+
+// /  Reads a big endian number of up to 16 bytes
+// /
+func u128_from_bytes(b []byte) U128 {
+	ret := U128{}
+	for _, c := range b {
+		ret = ret.Lsh(8).Or(U128FromUint64(uint64(c)))
+	}
+	return ret
+}
+
+// /  Returns the value as 16 big endian bytes
+// /
+func (self U128) bytes16() [16]byte {
+	var ret [16]byte
+	binary.BigEndian.PutUint64(ret[:8], self.Hi)
+	binary.BigEndian.PutUint64(ret[8:], self.Lo)
+	return ret
+}
+
+// /  Returns the number of trailing zero bits, 128 for zero
+// /
+func (self U128) trailing_zeros() uint {
+	if self.Lo != 0 {
+		return uint(bits.TrailingZeros64(self.Lo))
+	}
+	return 64 + uint(bits.TrailingZeros64(self.Hi))
+}
@@ -0,0 +1,62 @@
+package ipaddress
+
+import "testing"
+
+func TestU128(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestU128", func(t *MyTesting) {
+		t.Run("test_big_roundtrip", func(t *MyTesting) {
+			for _, str := range []string{"0", "1", "18446744073709551615",
+				"18446744073709551616", "340282366920938463463374607431768211455"} {
+				num, err := U128FromBig(str2IntPtr(str, 10))
+				t.assert(err == nil)
+				t.assert_string(str, num.String())
+				t.assert_bigint(str2Int(str, 10), *num.Big())
+			}
+			_, err := U128FromBig(str2IntPtr("340282366920938463463374607431768211456", 10))
+			t.assert(err != nil)
+			_, err = U128FromBig(str2IntPtr("-1", 10))
+			t.assert(err != nil)
+		})
+		t.Run("test_add_sub_carry", func(t *MyTesting) {
+			max64 := U128FromUint64(^uint64(0))
+			t.assert(max64.Add(U128FromUint64(1)) == U128New(1, 0))
+			t.assert(U128New(1, 0).Sub(U128FromUint64(1)) == max64)
+			t.assert(u128_ones(128).Add(U128FromUint64(1)).Is_zero())
+		})
+		t.Run("test_shift", func(t *MyTesting) {
+			one := U128FromUint64(1)
+			t.assert(one.Lsh(64) == U128New(1, 0))
+			t.assert(one.Lsh(127) == U128New(1<<63, 0))
+			t.assert(one.Lsh(128).Is_zero())
+			t.assert(U128New(1, 0).Rsh(64) == one)
+			t.assert(U128New(1, 0).Rsh(1) == U128New(0, 1<<63))
+			t.assert(u128_ones(32) == U128FromUint64(0xffffffff))
+			t.assert(u128_ones(0).Is_zero())
+		})
+		t.Run("test_text", func(t *MyTesting) {
+			t.assert_string("a000001", U128FromUint64(0x0a000001).Text(16))
+			t.assert_string("10000000000000001", U128New(1, 1).Text(16))
+			t.assert_string("1"+"000000000000000000000000000000000000000000000000000000000000000"+"1",
+				U128New(1, 1).Text(2))
+		})
+		t.Run("test_hot_path_allocations", func(t *MyTesting) {
+			net := Parse("10.0.0.0/8").Unwrap()
+			ip := Parse("10.1.2.3/32").Unwrap()
+			net6 := Parse("2001:db8::/32").Unwrap()
+			ip6 := Parse("2001:db8::1/128").Unwrap()
+			allocs := testing.AllocsPerRun(100, func() {
+				net.Includes(ip)
+				net6.Includes(ip6)
+				net.Cmp(ip)
+				net6.Cmp(ip6)
+				net.Network()
+				net6.Network()
+				ip.Broadcast()
+				net.Broadcast()
+				net6.Broadcast()
+			})
+			t.assert_int(0, int(allocs))
+		})
+	})
+}
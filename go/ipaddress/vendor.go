@@ -0,0 +1,129 @@
+package ipaddress
+
+import "strings"
+
+// /  Parses the notations network equipment uses besides CIDR:
+// /
+// /  * "10.0.0.0/24" and "10.0.0.0/255.255.255.0" like Parse
+// /  * "10.0.0.0 255.255.255.0" an address and a netmask
+// /  * "10.0.0.0 0.0.0.255" an address and a wildcard mask
+// /  * "10.0.0.0 mask 255.255.0.0" an address and a netmask
+// /  * "host 10.0.0.1" a single host
+// /  * "any" every IPv4 address
+// /  * "10.0.*.*" octet wildcards, which have to be trailing
+// /
+// /    ip, _ := ParseVendor("10.0.0.0 0.0.0.255")
+// /    ip.To_string()
+// /      ///  "10.0.0.0/24"
+// /
+// /  A mask of two fields is a netmask if its leading bit is
+// /  set, otherwise it is a wildcard mask. So "0.0.0.0" is a host
+// /  and "255.255.255.255" is a /32 netmask, use "any" for the
+// /  wildcard match of every address.
+// /
+// /  Masks which are neither contiguous netmasks nor contiguous
+// /  wildcard masks, like "0.0.255.0", return an error which
+// /  unwraps to ErrNonContiguousMask.
+// /
+func ParseVendor(str string, opts ...ParseOptions) (*IPAddress, error) {
+	fields := strings.Fields(str)
+	switch len(fields) {
+	case 1:
+		if fields[0] == "any" {
+			return ParseE("0.0.0.0/0", opts...)
+		}
+		if strings.Contains(fields[0], "*") {
+			return parse_glob(str, fields[0], opts...)
+		}
+		return ParseE(str, opts...)
+	case 2:
+		if fields[0] == "host" {
+			return parse_vendor_host(str, fields[1], opts...)
+		}
+		return parse_vendor_mask(str, fields[0], fields[1], false, opts...)
+	case 3:
+		if fields[1] == "mask" {
+			return parse_vendor_mask(str, fields[0], fields[2], true, opts...)
+		}
+	}
+	return nil, new_parse_error(ErrInvalidAddress, str, 0, "unknown notation")
+}
+
+func field_ofs(str string, field string) int {
+	return strings.Index(str, field)
+}
+
+func parse_vendor_host(str string, addr string, opts ...ParseOptions) (*IPAddress, error) {
+	ip, err := ParseE(addr, opts...)
+	if err != nil {
+		return nil, err.(*ParseError).rebase(str, field_ofs(str, addr))
+	}
+	if ip.Prefix.Num != ip.Ip_bits.Bits {
+		return nil, new_parse_error(ErrPrefixRange, str, field_ofs(str, addr), "host must not have a prefix")
+	}
+	return ip, nil
+}
+
+func parse_vendor_mask(str string, addr string, mask string, netmask_only bool, opts ...ParseOptions) (*IPAddress, error) {
+	ip, err := ParseE(addr, opts...)
+	if err != nil {
+		return nil, err.(*ParseError).rebase(str, field_ofs(str, addr))
+	}
+	mask_ofs := strings.LastIndex(str, mask)
+	if ip.Prefix.Num != ip.Ip_bits.Bits {
+		return nil, new_parse_error(ErrPrefixRange, str, field_ofs(str, addr), "address must not have a prefix")
+	}
+	mask_ip, err := ParseE(mask, opts...)
+	if err != nil || !mask_ip.Is_same_kind(ip) || mask_ip.Prefix.Num != mask_ip.Ip_bits.Bits {
+		return nil, new_parse_error(ErrBadNetmask, str, mask_ofs, "illegal netmask %q", mask)
+	}
+	bits := ip.Ip_bits.Bits
+	nm := mask_ip.Host_address
+	if !netmask_only && nm.Rsh(uint(bits-1)).Is_zero() {
+		// wildcard masks are inverted netmasks
+		nm = nm.Not().And(u128_ones(bits))
+	}
+	prefix, perr := netmask_to_prefix(nm, bits)
+	if perr != nil {
+		return nil, perr.rebase(str, mask_ofs)
+	}
+	return ip.Change_prefix(prefix).Unwrap(), nil
+}
+
+func parse_glob(str string, glob string, opts ...ParseOptions) (*IPAddress, error) {
+	glob_ofs := field_ofs(str, glob)
+	parts, parts_ofs := split_ofs(glob, ".")
+	if len(parts) != 4 {
+		return nil, new_parse_error(ErrInvalidAddress, str, glob_ofs, "wildcards need 4 octets")
+	}
+	prefix := uint8(0)
+	wild := false
+	for idx, part := range parts {
+		if part == "*" {
+			parts[idx] = "0"
+			wild = true
+		} else if wild || strings.Contains(part, "*") {
+			return nil, new_parse_error(ErrNonContiguousMask, str, glob_ofs+parts_ofs[idx],
+				"wildcards have to be trailing full octets")
+		} else {
+			prefix += 8
+		}
+	}
+	ip, err := ParseE(strings.Join(parts, "."), opts...)
+	if err != nil {
+		return nil, err.(*ParseError).rebase(str, glob_ofs)
+	}
+	return ip.Change_prefix(prefix).Unwrap(), nil
+}
+
+// /  Returns the wildcard mask, the inverted netmask, which
+// /  is used by access lists
+// /
+// /    ip = IPAddress("10.0.0.0/24")
+// /
+// /    ip.Wildcard().To_s()
+// /      ///  "0.0.0.255"
+// /
+func (self *IPAddress) Wildcard() *IPAddress {
+	return self.From(self.Prefix.Host_mask(), &self.Prefix)
+}
@@ -0,0 +1,77 @@
+package ipaddress
+
+import "errors"
+import "testing"
+
+func TestVendor(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestVendor", func(t *MyTesting) {
+		parse := func(str string) string {
+			ip, err := ParseVendor(str)
+			if err != nil {
+				return err.Error()
+			}
+			return ip.To_string()
+		}
+		t.Run("test_cidr", func(t *MyTesting) {
+			t.assert_string("10.0.0.0/24", parse("10.0.0.0/24"))
+			t.assert_string("10.0.0.0/24", parse("10.0.0.0/255.255.255.0"))
+			t.assert_string("2001:db8::/32", parse("2001:db8::/32"))
+		})
+		t.Run("test_netmask", func(t *MyTesting) {
+			t.assert_string("10.0.0.0/24", parse("10.0.0.0 255.255.255.0"))
+			t.assert_string("10.0.0.0/16", parse(" 10.0.0.0   mask 255.255.0.0 "))
+			t.assert_string("10.0.0.1/32", parse("10.0.0.1 255.255.255.255"))
+			t.assert_string("10.0.0.0/0", parse("10.0.0.0 mask 0.0.0.0"))
+			t.assert_string("2001:db8::/32", parse("2001:db8:: ffff:ffff::"))
+		})
+		t.Run("test_wildcard", func(t *MyTesting) {
+			t.assert_string("10.0.0.0/24", parse("10.0.0.0 0.0.0.255"))
+			t.assert_string("10.0.0.0/8", parse("10.0.0.0 0.255.255.255"))
+			t.assert_string("10.0.0.1/32", parse("10.0.0.1 0.0.0.0"))
+			t.assert_string("2001:db8::/64", parse("2001:db8:: ::ffff:ffff:ffff:ffff"))
+			t.assert_string("0.0.0.255", Parse("10.0.0.0/24").Unwrap().Wildcard().To_s())
+		})
+		t.Run("test_keywords", func(t *MyTesting) {
+			t.assert_string("0.0.0.0/0", parse("any"))
+			t.assert_string("10.0.0.1/32", parse("host 10.0.0.1"))
+			t.assert_string("2001:db8::1/128", parse("host 2001:db8::1"))
+		})
+		t.Run("test_glob", func(t *MyTesting) {
+			t.assert_string("10.0.0.0/16", parse("10.0.*.*"))
+			t.assert_string("10.1.2.0/24", parse("10.1.2.*"))
+			t.assert_string("0.0.0.0/0", parse("*.*.*.*"))
+		})
+		t.Run("test_non_contiguous", func(t *MyTesting) {
+			_, err := ParseVendor("10.0.0.0 0.0.255.0")
+			t.assert(errors.Is(err, ErrNonContiguousMask))
+			t.assert(errors.Is(err, ErrBadNetmask))
+			t.assert_int(9, parse_error_of(err).Offset)
+			_, err = ParseVendor("10.0.0.0 mask 255.0.255.0")
+			t.assert(errors.Is(err, ErrNonContiguousMask))
+			_, err = ParseVendor("10.*.0.*")
+			t.assert(errors.Is(err, ErrNonContiguousMask))
+			t.assert_int(5, parse_error_of(err).Offset)
+			_, err = ParseVendor("10.0.1*.*")
+			t.assert(errors.Is(err, ErrNonContiguousMask))
+			_, err = ParseE("10.0.0.0/255.0.255.0")
+			t.assert(errors.Is(err, ErrNonContiguousMask))
+		})
+		t.Run("test_errors", func(t *MyTesting) {
+			_, err := ParseVendor("10.0.0.0 2001:db8::")
+			t.assert(errors.Is(err, ErrBadNetmask))
+			_, err = ParseVendor("10.0.0.0 foo")
+			t.assert(errors.Is(err, ErrBadNetmask))
+			_, err = ParseVendor("10.0.0.0/8 255.0.0.0")
+			t.assert(errors.Is(err, ErrPrefixRange))
+			_, err = ParseVendor("10.0.0.256 255.0.0.0")
+			t.assert(errors.Is(err, ErrBadOctet))
+			_, err = ParseVendor("10.0.0.0 foo 255.0.0.0")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseVendor("10.0.*")
+			t.assert(errors.Is(err, ErrInvalidAddress))
+			_, err = ParseVendor("host 10.0.0.0/8")
+			t.assert(errors.Is(err, ErrPrefixRange))
+		})
+	})
+}
@@ -0,0 +1,108 @@
+package ipaddress
+
+import "strings"
+import "unicode"
+
+// /  IPv6 zone identifiers (RFC 4007), the scope of a link-local
+// /  address is written after a % sign:
+// /
+// /    ip = IPAddress("fe80::1%eth0/64")
+// /
+// /    ip.Zone
+// /      ///  "eth0"
+// /    ip.Network().To_string()
+// /      ///  "fe80::%eth0/64"
+// /
+// /  The %25 form used in URLs (RFC 6874) is accepted as well, so
+// /  "fe80::1%25eth0" is the same address as "fe80::1%eth0".
+// /
+
+// /  Checks if the given string can be used as zone. A zone must
+// /  not be empty and must not contain %, / or white space.
+// /
+// /    Is_valid_zone("eth0")
+// /      ///  true
+// /    Is_valid_zone("eth 0")
+// /      ///  false
+// /
+func Is_valid_zone(zone string) bool {
+	if zone == "" {
+		return false
+	}
+	for _, c := range zone {
+		if c == '%' || c == '/' || unicode.IsSpace(c) || unicode.IsControl(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// splits "fe80::1%eth0" into the address, the zone and the
+// offset of the zone within addr. A "%25" which is followed
+// by at least one character is the URL encoded % sign.
+func split_zone(addr string) (string, string, int, bool) {
+	pct := strings.IndexByte(addr, '%')
+	if pct < 0 {
+		return addr, "", 0, false
+	}
+	zone_ofs := pct + 1
+	if strings.HasPrefix(addr[zone_ofs:], "25") && len(addr) > zone_ofs+2 {
+		zone_ofs += 2
+	}
+	return addr[:pct], addr[zone_ofs:], zone_ofs, true
+}
+
+// removes the zone from str, the prefix is kept
+func strip_zone(str string) (string, bool) {
+	pct := strings.IndexByte(str, '%')
+	if pct < 0 {
+		return str, false
+	}
+	slash := strings.IndexByte(str[pct:], '/')
+	if slash < 0 {
+		return str[:pct], true
+	}
+	return str[:pct] + str[pct+slash:], true
+}
+
+func parse_zone(input string, zone string, ofs int) (string, *ParseError) {
+	if !Is_valid_zone(zone) {
+		return "", new_parse_error(ErrBadZone, input, ofs, "invalid zone %q", zone)
+	}
+	return zone, nil
+}
+
+func (self *IPAddress) zone_suffix() string {
+	if self.Zone == "" {
+		return ""
+	}
+	return "%" + self.Zone
+}
+
+// /  Returns a copy of the address with the given zone, only
+// /  IPv6 addresses can have a zone
+// /
+// /    ip = IPAddress("fe80::1/64")
+// /
+// /    ip.With_zone("eth0").To_string()
+// /      ///  "fe80::1%eth0/64"
+// /
+func (self *IPAddress) With_zone(zone string) (*IPAddress, error) {
+	if self.Is_ipv4() {
+		return nil, new_parse_error(ErrBadZone, self.To_string(), 0, "IPv4 has no zone %q", zone)
+	}
+	if _, err := parse_zone(zone, zone, 0); err != nil {
+		return nil, err
+	}
+	ret := *self
+	ret.Zone = zone
+	return &ret, nil
+}
+
+// /  Returns a copy of the address without zone
+// /
+func (self *IPAddress) Without_zone() *IPAddress {
+	ret := *self
+	ret.Zone = ""
+	return &ret
+}
@@ -0,0 +1,85 @@
+package ipaddress
+
+import "errors"
+import "testing"
+
+func TestZone(tx *testing.T) {
+	t := MyTesting{tx}
+	t.Run("TestZone", func(t *MyTesting) {
+		t.Run("test_parse", func(t *MyTesting) {
+			ip := Parse("fe80::1%eth0").Unwrap()
+			t.assert_string("eth0", ip.Zone)
+			t.assert_string("fe80::1%eth0", ip.To_s())
+			t.assert_string("fe80::1%eth0/128", ip.To_string())
+			ip = Parse("fe80::1%25eth0/64").Unwrap()
+			t.assert_string("eth0", ip.Zone)
+			t.assert_string("fe80::1%eth0/64", ip.To_string())
+			t.assert_string("fe80:0000:0000:0000:0000:0000:0000:0001%eth0/64", ip.To_string_uncompressed())
+			t.assert_string("eth0.100", Parse("fe80::1%eth0.100/64").Unwrap().Zone)
+			t.assert_string("25", Parse("fe80::1%25").Unwrap().Zone)
+			t.assert_string("", Parse("fe80::1/64").Unwrap().Zone)
+		})
+		t.Run("test_parse_errors", func(t *MyTesting) {
+			_, err := ParseE("fe80::1%/64")
+			t.assert(errors.Is(err, ErrBadZone))
+			t.assert_int(8, parse_error_of(err).Offset)
+			_, err = ParseE("10.0.0.1%eth0")
+			t.assert(errors.Is(err, ErrBadZone))
+			_, err = Ipv6NewE("fe80::1%eth 0")
+			t.assert(errors.Is(err, ErrBadZone))
+		})
+		t.Run("test_preserved", func(t *MyTesting) {
+			ip := Parse("fe80::1%eth0/64").Unwrap()
+			t.assert_string("fe80::%eth0/64", ip.Network().To_string())
+			t.assert_string("fe80::ffff:ffff:ffff:ffff%eth0/64", ip.Broadcast().To_string())
+			t.assert_string("eth0", ip.Clone().Zone)
+			t.assert_string("eth0", ip.From(U128FromUint64(2), &ip.Prefix).Zone)
+		})
+		t.Run("test_equality", func(t *MyTesting) {
+			a := Parse("fe80::1%eth0/64").Unwrap()
+			b := Parse("fe80::1%eth1/64").Unwrap()
+			c := Parse("fe80::1%25eth0/64").Unwrap()
+			t.assert(*a != *b)
+			t.assert(*a == *c)
+			t.assert(!a.Equal(*b))
+			t.assert(a.Equal(*c))
+			t.assert_int(-1, a.Cmp(b))
+			t.assert_int(1, a.Cmp(a.Without_zone()))
+		})
+		t.Run("test_helpers", func(t *MyTesting) {
+			t.assert(Is_valid_zone("eth0"))
+			t.assert(Is_valid_zone("3"))
+			t.assert(!Is_valid_zone(""))
+			t.assert(!Is_valid_zone("eth%0"))
+			t.assert(!Is_valid_zone("eth0/64"))
+			ip := Parse("fe80::1%eth0/64").Unwrap()
+			t.assert_string("fe80::1/64", ip.Without_zone().To_string())
+			ip, err := ip.With_zone("wlan0")
+			t.assert(err == nil)
+			t.assert_string("fe80::1%wlan0/64", ip.To_string())
+			_, err = Parse("10.0.0.1").Unwrap().With_zone("eth0")
+			t.assert(errors.Is(err, ErrBadZone))
+			_, err = ip.With_zone("")
+			t.assert(errors.Is(err, ErrBadZone))
+		})
+		t.Run("test_mapped", func(t *MyTesting) {
+			ip := Parse("::ffff:10.0.0.1%eth0").Unwrap()
+			t.assert(ip.Is_mapped())
+			t.assert_string("eth0", ip.Zone)
+			t.assert_string("::ffff:10.0.0.1%eth0", ip.To_s_mapped())
+			t.assert_string("", ip.Mapped().Zone)
+		})
+		t.Run("test_marshal", func(t *MyTesting) {
+			ip := Parse("fe80::1%eth0/64").Unwrap()
+			text, _ := ip.MarshalText()
+			var back IPAddress
+			t.assert(back.UnmarshalText(text) == nil)
+			t.assert(back == *ip)
+			bin, _ := ip.MarshalBinary()
+			t.assert_int(21, len(bin))
+			back = IPAddress{}
+			t.assert(back.UnmarshalBinary(bin) == nil)
+			t.assert(back == *ip)
+		})
+	})
+}